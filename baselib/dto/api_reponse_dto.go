@@ -6,27 +6,39 @@ import (
 )
 
 type Response[T any] struct {
-	ResponseCode    string    `json:"responseCode" example:"200"`                           // Http Response Code
-	ResponseMessage string    `json:"responseMessage" example:"Messages Messages Messages"` // Response Message
-	Data            T         `json:"data"`                                                 // Data (Any model)
-	LogReff         string    `json:"logReff" example:"LogReffLogReffLogReffLogReff"`       // LogReff (Use this to search in splunk)
-	TraceId         string    `json:"traceId" example:"TraceIdTraceIdTraceIdTraceId"`       // TraceId (Use this as trace id in jaeger)
-	PageInfo        *PageInfo `json:"pageInfo"`                                             // PageInfo (Only for response type list with pages)
+	ResponseCode    string       `json:"responseCode" example:"200"`                           // Http Response Code
+	ResponseMessage string       `json:"responseMessage" example:"Messages Messages Messages"` // Response Message
+	Data            T            `json:"data"`                                                 // Data (Any model)
+	LogReff         string       `json:"logReff" example:"LogReffLogReffLogReffLogReff"`       // LogReff (Use this to search in splunk)
+	TraceId         string       `json:"traceId" example:"TraceIdTraceIdTraceIdTraceId"`       // TraceId (Use this as trace id in jaeger)
+	PageInfo        *PageInfo    `json:"pageInfo"`                                             // PageInfo (Only for response type list with pages)
+	Errors          []FieldError `json:"errors,omitempty"`                                     // Errors (Only for validation failures)
+}
+
+type FieldError struct {
+	Field   string `json:"field" example:"sampleVersions[0].versionNumber"`         // Field Path
+	Rule    string `json:"rule" example:"max"`                                      // Failed Validation Rule
+	Param   string `json:"param,omitempty" example:"10"`                            // Rule Parameter
+	Message string `json:"message" example:"versionNumber must be a maximum of 10"` // Translated Message
 }
 
 type PageRequest struct {
-	Query         string `json:"search"`        // Search Query
-	Page          int    `json:"page"`          // Current Page of the Data
-	PageSize      int    `json:"pageSize"`      // Amount of Items Shown on the Page
-	SortBy        string `json:"sortBy"`        // Data to Sort By
-	SortDirection string `json:"sortDirection"` // Sort Direction of the Data
+	Query         string `json:"search"`               // Search Query
+	Page          int    `json:"page"`                 // Current Page of the Data
+	PageSize      int    `json:"pageSize"`             // Amount of Items Shown on the Page
+	SortBy        string `json:"sortBy"`               // Data to Sort By
+	SortDirection string `json:"sortDirection"`        // Sort Direction of the Data
+	Cursor        string `json:"cursor" form:"cursor"` // Keyset Cursor, "start" for the first page
+	Filter        string `json:"filter" form:"filter"` // Filter Expression, e.g. sampleType:in:Type_A,Type_B;createDate:gte:2024-01-01
 }
 
 type PageInfo struct {
-	CurrentPageIndex    int `json:"currentPageIndex" example:"1"`       // Current Page Index
-	MaxPageIndex        int `json:"maxPageIndex" example:"10"`          // Max Page Index
-	RowsPerPage         int `json:"rowsPerPage" example:"100"`          // Rows Per Page
-	TotalAvailableItems int `json:"totalAvailableItems" example:"1000"` // Total Available Items
+	CurrentPageIndex    int    `json:"currentPageIndex" example:"1"`       // Current Page Index
+	MaxPageIndex        int    `json:"maxPageIndex" example:"10"`          // Max Page Index
+	RowsPerPage         int    `json:"rowsPerPage" example:"100"`          // Rows Per Page
+	TotalAvailableItems int    `json:"totalAvailableItems" example:"1000"` // Total Available Items
+	NextCursor          string `json:"nextCursor,omitempty"`               // Cursor of the Next Page (Keyset mode only)
+	PrevCursor          string `json:"prevCursor,omitempty"`               // Cursor of the Previous Page (Keyset mode only)
 }
 
 func (p *PageRequest) GetOrderString(baseKey string, allowedOrder string) (orderString string) {
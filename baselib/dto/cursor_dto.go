@@ -0,0 +1,46 @@
+package dto
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+const (
+	PAGE_CURSOR_START string = "start"
+	PAGE_CURSOR_NEXT  string = "next"
+	PAGE_CURSOR_PREV  string = "prev"
+)
+
+// Cursor is the decoded form of the opaque keyset token, it carries the sort
+// of the listing so following pages keep the order of the first one.
+type Cursor struct {
+	Direction     string        `json:"d"`
+	SortBy        string        `json:"s,omitempty"`
+	SortDirection string        `json:"o"`
+	Values        []interface{} `json:"v"`
+}
+
+func (c Cursor) Encode() string {
+	payload, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(payload)
+}
+
+func DecodeCursor(token string) (*Cursor, error) {
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor Cursor
+	err = json.Unmarshal(payload, &cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cursor, nil
+}
+
+// IsKeyset reports whether the request asks for cursor pagination instead of LIMIT/OFFSET.
+func (p *PageRequest) IsKeyset() bool {
+	return p.Cursor != ""
+}
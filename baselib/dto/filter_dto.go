@@ -0,0 +1,114 @@
+package dto
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	FILTER_OPERATOR_EQ       string = "eq"
+	FILTER_OPERATOR_NE       string = "ne"
+	FILTER_OPERATOR_IN       string = "in"
+	FILTER_OPERATOR_NIN      string = "nin"
+	FILTER_OPERATOR_LIKE     string = "like"
+	FILTER_OPERATOR_GT       string = "gt"
+	FILTER_OPERATOR_GTE      string = "gte"
+	FILTER_OPERATOR_LT       string = "lt"
+	FILTER_OPERATOR_LTE      string = "lte"
+	FILTER_OPERATOR_BETWEEN  string = "between"
+	FILTER_OPERATOR_NULL     string = "null"
+	FILTER_OPERATOR_NOT_NULL string = "notnull"
+)
+
+// FilterCondition is one field:operator:value term of a filter expression.
+type FilterCondition struct {
+	Field    string
+	Operator string
+	Values   []string
+}
+
+// ParseFilter parses expressions such as
+// "sampleType:in:Type_A,Type_B;createDate:gte:2024-01-01". Terms are separated
+// by ';', list values by ',' and a '\' escapes the next character.
+func ParseFilter(filter string) ([]FilterCondition, error) {
+	conditions := []FilterCondition{}
+
+	for _, term := range splitEscaped(filter, ';', false) {
+		if strings.TrimSpace(term) == "" {
+			continue
+		}
+
+		parts := strings.SplitN(term, ":", 3)
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("filter %q must be field:operator[:value]", unescape(term))
+		}
+
+		condition := FilterCondition{
+			Field:    strings.TrimSpace(parts[0]),
+			Operator: strings.ToLower(strings.TrimSpace(parts[1])),
+		}
+		if len(parts) == 3 {
+			condition.Values = []string{unescape(parts[2])}
+		}
+
+		expected := 1
+		switch condition.Operator {
+		case FILTER_OPERATOR_EQ, FILTER_OPERATOR_NE, FILTER_OPERATOR_LIKE,
+			FILTER_OPERATOR_GT, FILTER_OPERATOR_GTE, FILTER_OPERATOR_LT, FILTER_OPERATOR_LTE:
+		case FILTER_OPERATOR_IN, FILTER_OPERATOR_NIN:
+			expected = -1
+			if len(parts) == 3 {
+				condition.Values = splitEscaped(parts[2], ',', true)
+			}
+		case FILTER_OPERATOR_BETWEEN:
+			expected = 2
+			if len(parts) == 3 {
+				condition.Values = splitEscaped(parts[2], ',', true)
+			}
+		case FILTER_OPERATOR_NULL, FILTER_OPERATOR_NOT_NULL:
+			expected = 0
+		default:
+			return nil, fmt.Errorf("filter %q has an unknown operator %q", condition.Field, condition.Operator)
+		}
+
+		if (expected >= 0 && len(condition.Values) != expected) || (expected < 0 && len(condition.Values) == 0) {
+			return nil, fmt.Errorf("filter %q has a wrong number of values for %q", condition.Field, condition.Operator)
+		}
+
+		conditions = append(conditions, condition)
+	}
+
+	return conditions, nil
+}
+
+// splitEscaped splits s on sep while honouring '\' escapes, the escapes are
+// only removed when final is set so nested splits still see them.
+func splitEscaped(s string, sep rune, final bool) []string {
+	parts := []string{}
+	current := strings.Builder{}
+	escaped := false
+
+	for _, r := range s {
+		switch {
+		case escaped:
+			if !final {
+				current.WriteRune('\\')
+			}
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == sep:
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+
+	return append(parts, current.String())
+}
+
+func unescape(s string) string {
+	return strings.Join(splitEscaped(s, 0, true), "")
+}
@@ -0,0 +1,16 @@
+package dto
+
+const PROBLEM_CONTENT_TYPE string = "application/problem+json"
+
+// Problem is an RFC 7807 problem detail, members after Instance are extensions.
+type Problem struct {
+	Type     string       `json:"type" example:"about:blank"`                       // Problem Type URI
+	Title    string       `json:"title" example:"Bad Request"`                      // Short Summary of the Problem Type
+	Status   int          `json:"status" example:"400"`                             // Http Status Code
+	Detail   string       `json:"detail,omitempty" example:"Sample ID is required"` // Explanation of this Occurrence
+	Instance string       `json:"instance,omitempty" example:"/api/v1/sample"`      // Request that Caused the Problem
+	Code     string       `json:"code" example:"400"`                               // Application Error Code
+	LogReff  string       `json:"logReff" example:"LogReffLogReffLogReffLogReff"`   // LogReff (Use this to search in splunk)
+	TraceId  string       `json:"traceId" example:"TraceIdTraceIdTraceIdTraceId"`   // TraceId (Use this as trace id in jaeger)
+	Errors   []FieldError `json:"errors,omitempty"`                                 // Errors (Only for validation failures)
+}
@@ -0,0 +1,151 @@
+package exception
+
+import (
+	"fmt"
+	"gogin-template/baselib/identifier"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Params fills the {name} placeholders of a catalog message.
+type Params map[string]interface{}
+
+type CatalogEntry struct {
+	Code           string
+	HttpStatusCode int
+	Message        string // Default english template
+}
+
+var (
+	catalogMu    sync.RWMutex
+	catalog      = map[string]CatalogEntry{}
+	translations = map[string]map[string]string{}
+)
+
+var (
+	ERROR_INVALID_REQUEST       = Register("GEN-0001", http.StatusBadRequest, "The request could not be read")
+	ERROR_VALIDATION_FAILED     = Register("GEN-0002", http.StatusBadRequest, "Validation failed")
+	ERROR_INVALID_CURSOR        = Register("GEN-0003", http.StatusBadRequest, "The pagination cursor is invalid")
+	ERROR_INVALID_FILTER        = Register("GEN-0004", http.StatusBadRequest, "The filter expression is invalid: {reason}")
+	ERROR_UNKNOWN_FILTER_FIELD  = Register("GEN-0005", http.StatusBadRequest, "Unknown filter field {field}")
+	ERROR_UNAUTHORIZED          = Register("GEN-0010", http.StatusUnauthorized, "Unauthorized")
+	ERROR_MISSING_TOKEN         = Register("GEN-0011", http.StatusUnauthorized, "Missing bearer token")
+	ERROR_INVALID_TOKEN         = Register("GEN-0012", http.StatusUnauthorized, "Invalid bearer token")
+	ERROR_TOKEN_WITHOUT_SUBJECT = Register("GEN-0013", http.StatusUnauthorized, "Bearer token has no subject")
+	ERROR_FORBIDDEN             = Register("GEN-0020", http.StatusForbidden, "Forbidden")
+	ERROR_NOT_FOUND             = Register("GEN-0030", http.StatusNotFound, "Not found")
+	ERROR_INTERNAL              = Register("GEN-0500", http.StatusInternalServerError, "Internal server error")
+	ERROR_INVALID_RESPONSE      = Register("GEN-0502", http.StatusBadGateway, "{method} {path} returned a response that could not be read")
+)
+
+// Register adds a business error code to the catalog and returns the code, so
+// catalogs can be declared as package variables.
+func Register(code string, httpStatusCode int, message string) string {
+	catalogMu.Lock()
+	defer catalogMu.Unlock()
+
+	if _, ok := catalog[code]; ok {
+		panic("exception: error code " + code + " is registered twice")
+	}
+	catalog[code] = CatalogEntry{Code: code, HttpStatusCode: httpStatusCode, Message: message}
+
+	return code
+}
+
+// New builds the exception of a catalog code, unknown codes become a 500.
+func New(code string, params Params) *ErrorException {
+	catalogMu.RLock()
+	entry, ok := catalog[code]
+	catalogMu.RUnlock()
+
+	if !ok {
+		entry = CatalogEntry{Code: code, HttpStatusCode: http.StatusInternalServerError, Message: code}
+	}
+
+	return &ErrorException{
+		ErrorCode:      entry.Code,
+		ErrorMessage:   formatMessage(entry.Message, params),
+		HttpStatusCode: entry.HttpStatusCode,
+		Params:         params,
+	}
+}
+
+// LoadTranslations reads <locale>.yaml files mapping error codes to message
+// templates, e.g. id.yaml for Indonesian.
+func LoadTranslations(dir string) error {
+	files, err := filepath.Glob(filepath.Join(dir, "*.y*ml"))
+	if err != nil {
+		return err
+	}
+
+	loaded := map[string]map[string]string{}
+	for _, file := range files {
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+
+		messages := map[string]string{}
+		err = yaml.Unmarshal(content, &messages)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file, err)
+		}
+
+		locale := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+		loaded[strings.ToLower(locale)] = messages
+	}
+
+	catalogMu.Lock()
+	translations = loaded
+	catalogMu.Unlock()
+
+	return nil
+}
+
+// Locales lists the default locale followed by every loaded translation.
+func Locales() []string {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	locales := []string{}
+	for locale := range translations {
+		if locale != identifier.DEFAULT_LOCALE {
+			locales = append(locales, locale)
+		}
+	}
+	sort.Strings(locales)
+
+	return append([]string{identifier.DEFAULT_LOCALE}, locales...)
+}
+
+// Localize returns the message of e in locale, exceptions whose code is not in
+// the catalog keep their own message.
+func (e *ErrorException) Localize(locale string) string {
+	catalogMu.RLock()
+	defer catalogMu.RUnlock()
+
+	entry, ok := catalog[e.ErrorCode]
+	if !ok {
+		return e.ErrorMessage
+	}
+
+	if message, ok := translations[strings.ToLower(locale)][e.ErrorCode]; ok {
+		return formatMessage(message, e.Params)
+	}
+
+	return formatMessage(entry.Message, e.Params)
+}
+
+func formatMessage(template string, params Params) string {
+	for name, value := range params {
+		template = strings.ReplaceAll(template, "{"+name+"}", fmt.Sprint(value))
+	}
+
+	return template
+}
@@ -0,0 +1,98 @@
+package exception
+
+import (
+	"gogin-template/baselib/identifier"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+var (
+	catalogTestPlain  = Register("TST-0001", http.StatusConflict, "Sample {sampleId} is locked")
+	catalogTestNoText = Register("TST-0002", http.StatusBadRequest, "Only in english")
+)
+
+func catalogTestTranslations(t *testing.T, files map[string]string) {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	previous := translations
+	t.Cleanup(func() { translations = previous })
+
+	if err := LoadTranslations(dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestLocalize(t *testing.T) {
+	catalogTestTranslations(t, map[string]string{
+		"id.yaml": `TST-0001: "Sampel {sampleId} sedang dikunci"`,
+		"ms.yml":  `TST-0001: "Sampel {sampleId} dikunci"`,
+	})
+
+	tests := []struct {
+		name    string
+		err     *ErrorException
+		locale  string
+		message string
+	}{
+		{name: "default locale", err: New(catalogTestPlain, Params{"sampleId": "S1"}), locale: identifier.DEFAULT_LOCALE, message: "Sample S1 is locked"},
+		{name: "translated", err: New(catalogTestPlain, Params{"sampleId": "S1"}), locale: "id", message: "Sampel S1 sedang dikunci"},
+		{name: "locale case", err: New(catalogTestPlain, Params{"sampleId": "S1"}), locale: "ID", message: "Sampel S1 sedang dikunci"},
+		{name: "yml extension", err: New(catalogTestPlain, Params{"sampleId": "S1"}), locale: "ms", message: "Sampel S1 dikunci"},
+		{name: "unknown locale falls back", err: New(catalogTestPlain, Params{"sampleId": "S1"}), locale: "fr", message: "Sample S1 is locked"},
+		{name: "missing translation falls back", err: New(catalogTestNoText, nil), locale: "id", message: "Only in english"},
+		{name: "empty locale falls back", err: New(catalogTestNoText, nil), locale: "", message: "Only in english"},
+		{name: "code outside the catalog keeps its message", err: &ErrorException{ErrorCode: "X-1", ErrorMessage: "custom"}, locale: "id", message: "custom"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if message := test.err.Localize(test.locale); message != test.message {
+				t.Errorf("message = %q, want %q", message, test.message)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	e := New(catalogTestPlain, Params{"sampleId": "S1"})
+	if e.HttpStatusCode != http.StatusConflict || e.ErrorMessage != "Sample S1 is locked" {
+		t.Errorf("exception = %+v, want the catalog status and message", e)
+	}
+
+	e = New("TST-9999", nil)
+	if e.HttpStatusCode != http.StatusInternalServerError || e.ErrorCode != "TST-9999" {
+		t.Errorf("exception = %+v, want an internal error keeping the code", e)
+	}
+}
+
+func TestLocales(t *testing.T) {
+	catalogTestTranslations(t, map[string]string{
+		"ms.yaml": `TST-0001: "x"`,
+		"id.yaml": `TST-0001: "y"`,
+		"en.yaml": `TST-0001: "z"`,
+	})
+
+	if locales := Locales(); !reflect.DeepEqual(locales, []string{identifier.DEFAULT_LOCALE, "id", "ms"}) {
+		t.Errorf("locales = %v, want the default locale first", locales)
+	}
+}
+
+func TestRegisterTwicePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Register did not panic on a duplicate code")
+		}
+	}()
+
+	Register(catalogTestPlain, http.StatusBadRequest, "again")
+}
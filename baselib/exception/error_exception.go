@@ -2,6 +2,7 @@ package exception
 
 import (
 	"fmt"
+	"gogin-template/baselib/dto"
 	"net/http"
 	"strconv"
 )
@@ -10,12 +11,35 @@ type ErrorException struct {
 	ErrorCode      string
 	ErrorMessage   string
 	HttpStatusCode int
+	Errors         []dto.FieldError
+	Params         Params
+	Cause          error
 }
 
 func (e *ErrorException) Error() string {
+	if e.Cause != nil {
+		return fmt.Sprintf("Error : %s - %s: %s", e.ErrorCode, e.ErrorMessage, e.Cause)
+	}
+
 	return fmt.Sprintf("Error : %s - %s", e.ErrorCode, e.ErrorMessage)
 }
 
+func (e *ErrorException) Unwrap() error {
+	return e.Cause
+}
+
+// WithCause keeps the underlying error for the logs without showing it to clients.
+func (e *ErrorException) WithCause(err error) *ErrorException {
+	e.Cause = err
+	return e
+}
+
+// WithErrors attaches field level details.
+func (e *ErrorException) WithErrors(errors []dto.FieldError) *ErrorException {
+	e.Errors = errors
+	return e
+}
+
 func NotFoundException(errorCode string, errorMessage string) *ErrorException {
 	if errorCode == "" {
 		errorCode = strconv.Itoa(http.StatusNotFound)
@@ -40,6 +64,13 @@ func ValidationException(errorCode string, errorMessage string) *ErrorException
 	}
 }
 
+func FieldValidationException(errorCode string, errorMessage string, errors []dto.FieldError) *ErrorException {
+	e := ValidationException(errorCode, errorMessage)
+	e.Errors = errors
+
+	return e
+}
+
 func UnhandledException(errorCode string, errorMessage string) *ErrorException {
 	if errorCode == "" {
 		errorCode = strconv.Itoa(http.StatusInternalServerError)
@@ -63,3 +94,15 @@ func UnauthorizedException(errorCode string, errorMessage string) *ErrorExceptio
 		HttpStatusCode: http.StatusUnauthorized,
 	}
 }
+
+func ForbiddenException(errorCode string, errorMessage string) *ErrorException {
+	if errorCode == "" {
+		errorCode = strconv.Itoa(http.StatusForbidden)
+	}
+
+	return &ErrorException{
+		ErrorCode:      errorCode,
+		ErrorMessage:   errorMessage,
+		HttpStatusCode: http.StatusForbidden,
+	}
+}
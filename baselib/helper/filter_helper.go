@@ -0,0 +1,86 @@
+package helper
+
+import (
+	"gogin-template/baselib/dto"
+	"gogin-template/baselib/exception"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// RepoPGGetFilter compiles a dto filter expression into " AND ..." conditions
+// over the db columns of typ. Fields may be written as the db column, the Go
+// field name or its camelCase form; placeholders start at $argIndex.
+func RepoPGGetFilter(typ reflect.Type, filter string, argIndex int) (string, []interface{}, error) {
+	conditions, err := dto.ParseFilter(filter)
+	if err != nil {
+		return "", nil, exception.New(exception.ERROR_INVALID_FILTER, exception.Params{"reason": err.Error()})
+	}
+
+	columns := map[string]string{}
+	for i := 0; i < typ.NumField(); i++ {
+		objectField := typ.Field(i)
+		if tagValue, ok := objectField.Tag.Lookup("db"); ok {
+			columns[filterFieldKey(tagValue)] = tagValue
+			columns[filterFieldKey(objectField.Name)] = tagValue
+		}
+	}
+
+	query := ""
+	args := []interface{}{}
+	placeholder := func(value interface{}) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(argIndex+len(args)-1)
+	}
+
+	for _, condition := range conditions {
+		column, ok := columns[filterFieldKey(condition.Field)]
+		if !ok {
+			return "", nil, exception.New(exception.ERROR_UNKNOWN_FILTER_FIELD, exception.Params{"field": condition.Field})
+		}
+
+		switch condition.Operator {
+		case dto.FILTER_OPERATOR_EQ:
+			query += ` AND ` + column + ` = ` + placeholder(condition.Values[0])
+		case dto.FILTER_OPERATOR_NE:
+			query += ` AND ` + column + ` IS DISTINCT FROM ` + placeholder(condition.Values[0])
+		case dto.FILTER_OPERATOR_GT:
+			query += ` AND ` + column + ` > ` + placeholder(condition.Values[0])
+		case dto.FILTER_OPERATOR_GTE:
+			query += ` AND ` + column + ` >= ` + placeholder(condition.Values[0])
+		case dto.FILTER_OPERATOR_LT:
+			query += ` AND ` + column + ` < ` + placeholder(condition.Values[0])
+		case dto.FILTER_OPERATOR_LTE:
+			query += ` AND ` + column + ` <= ` + placeholder(condition.Values[0])
+		case dto.FILTER_OPERATOR_BETWEEN:
+			query += ` AND ` + column + ` BETWEEN ` + placeholder(condition.Values[0]) + ` AND ` + placeholder(condition.Values[1])
+		case dto.FILTER_OPERATOR_LIKE:
+			// A value without wildcards matches anywhere in the column.
+			pattern := condition.Values[0]
+			if !strings.ContainsAny(pattern, "%_") {
+				pattern = "%" + pattern + "%"
+			}
+			query += ` AND ` + column + `::text ILIKE ` + placeholder(pattern)
+		case dto.FILTER_OPERATOR_IN, dto.FILTER_OPERATOR_NIN:
+			placeholders := make([]string, len(condition.Values))
+			for i, value := range condition.Values {
+				placeholders[i] = placeholder(value)
+			}
+			operator := ` IN (`
+			if condition.Operator == dto.FILTER_OPERATOR_NIN {
+				operator = ` NOT IN (`
+			}
+			query += ` AND ` + column + operator + strings.Join(placeholders, ", ") + `)`
+		case dto.FILTER_OPERATOR_NULL:
+			query += ` AND ` + column + ` IS NULL`
+		case dto.FILTER_OPERATOR_NOT_NULL:
+			query += ` AND ` + column + ` IS NOT NULL`
+		}
+	}
+
+	return query, args, nil
+}
+
+func filterFieldKey(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
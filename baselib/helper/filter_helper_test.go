@@ -0,0 +1,133 @@
+package helper
+
+import (
+	"errors"
+	"gogin-template/baselib/exception"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type filterTestModel struct {
+	SampleId   string     `db:"sample_id"`
+	SampleType string     `db:"sample_type"`
+	CreateDate *time.Time `db:"create_date"`
+	Internal   string
+}
+
+func TestRepoPGGetFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		query  string
+		args   []interface{}
+	}{
+		{
+			name:   "empty",
+			filter: "",
+			query:  "",
+			args:   []interface{}{},
+		},
+		{
+			name:   "equal by camel case field",
+			filter: "sampleType:eq:Type_A",
+			query:  " AND sample_type = $3",
+			args:   []interface{}{"Type_A"},
+		},
+		{
+			name:   "column name and operator case",
+			filter: "sample_type:NE:Type_A",
+			query:  " AND sample_type IS DISTINCT FROM $3",
+			args:   []interface{}{"Type_A"},
+		},
+		{
+			name:   "in with escaped separator",
+			filter: `sampleId:in:A,B\,C`,
+			query:  " AND sample_id IN ($3, $4)",
+			args:   []interface{}{"A", "B,C"},
+		},
+		{
+			name:   "not in",
+			filter: "sampleId:nin:A",
+			query:  " AND sample_id NOT IN ($3)",
+			args:   []interface{}{"A"},
+		},
+		{
+			name:   "between and null",
+			filter: "createDate:between:2024-01-01,2024-12-31;sampleType:null",
+			query:  " AND create_date BETWEEN $3 AND $4 AND sample_type IS NULL",
+			args:   []interface{}{"2024-01-01", "2024-12-31"},
+		},
+		{
+			name:   "value keeps colons",
+			filter: "createDate:gte:2024-01-01T10:00:00Z",
+			query:  " AND create_date >= $3",
+			args:   []interface{}{"2024-01-01T10:00:00Z"},
+		},
+		{
+			name:   "like wraps plain values",
+			filter: "sampleId:like:abc",
+			query:  " AND sample_id::text ILIKE $3",
+			args:   []interface{}{"%abc%"},
+		},
+		{
+			name:   "like keeps wildcards",
+			filter: "sampleId:like:abc%",
+			query:  " AND sample_id::text ILIKE $3",
+			args:   []interface{}{"abc%"},
+		},
+		{
+			name:   "injection stays a bound value",
+			filter: "sampleId:eq:x' OR '1'='1",
+			query:  " AND sample_id = $3",
+			args:   []interface{}{"x' OR '1'='1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			query, args, err := RepoPGGetFilter(reflect.TypeOf(filterTestModel{}), test.filter, 3)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if query != test.query {
+				t.Errorf("query = %q, want %q", query, test.query)
+			}
+			if !reflect.DeepEqual(args, test.args) {
+				t.Errorf("args = %v, want %v", args, test.args)
+			}
+		})
+	}
+}
+
+func TestRepoPGGetFilterRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter string
+		code   string
+	}{
+		{name: "missing operator", filter: "sampleId", code: exception.ERROR_INVALID_FILTER},
+		{name: "unknown operator", filter: "sampleId:regex:.*", code: exception.ERROR_INVALID_FILTER},
+		{name: "missing value", filter: "sampleId:eq", code: exception.ERROR_INVALID_FILTER},
+		{name: "between needs two values", filter: "createDate:between:2024-01-01", code: exception.ERROR_INVALID_FILTER},
+		{name: "null takes no value", filter: "sampleType:null:x", code: exception.ERROR_INVALID_FILTER},
+		{name: "unknown field", filter: "password:eq:x", code: exception.ERROR_UNKNOWN_FILTER_FIELD},
+		{name: "field without db tag", filter: "internal:eq:x", code: exception.ERROR_UNKNOWN_FILTER_FIELD},
+		{name: "sql as field", filter: "sample_id = sample_id OR 1:eq:1", code: exception.ERROR_UNKNOWN_FILTER_FIELD},
+		{name: "sql as operator", filter: "sampleId:= 1 OR 1 =:1", code: exception.ERROR_INVALID_FILTER},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := RepoPGGetFilter(reflect.TypeOf(filterTestModel{}), test.filter, 1)
+
+			var e *exception.ErrorException
+			if !errors.As(err, &e) {
+				t.Fatalf("error = %v, want exception %s", err, test.code)
+			}
+			if e.ErrorCode != test.code {
+				t.Errorf("code = %s, want %s", e.ErrorCode, test.code)
+			}
+		})
+	}
+}
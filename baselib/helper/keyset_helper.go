@@ -0,0 +1,216 @@
+package helper
+
+import (
+	"gogin-template/baselib/dto"
+	"gogin-template/baselib/exception"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const KEYSET_DEFAULT_PAGE_SIZE int = 10
+
+// Keyset is the seek condition of one cursor page. Columns are the optional
+// dbx:"sort" column followed by every dbx:"key" column, so the order is total.
+// The sort column may hold NULLs, which come after every value in either sort
+// direction; key columns are expected to be NOT NULL.
+type Keyset struct {
+	Columns  []string
+	Args     []interface{}
+	PageSize int
+	cursor   dto.Cursor
+	start    bool
+	sorted   bool // Columns[0] is the sort column
+	sortNull bool // The cursor row has a NULL sort value, which is not bound
+}
+
+// RepoPGGetKeyset resolves the cursor of dtoPage against the dbx tags of typ.
+func RepoPGGetKeyset(typ reflect.Type, dtoPage dto.PageRequest) (*Keyset, error) {
+	keys, _, _, _, order := RepoPGGetColumns(typ)
+
+	keyset := &Keyset{PageSize: dtoPage.PageSize, start: dtoPage.Cursor == dto.PAGE_CURSOR_START}
+	if keyset.PageSize <= 0 {
+		keyset.PageSize = KEYSET_DEFAULT_PAGE_SIZE
+	}
+
+	if keyset.start {
+		keyset.cursor = dto.Cursor{Direction: dto.PAGE_CURSOR_NEXT, SortBy: strings.ToLower(dtoPage.SortBy), SortDirection: dtoPage.SortDirection}
+	} else {
+		cursor, err := dto.DecodeCursor(dtoPage.Cursor)
+		if err != nil {
+			return nil, exception.New(exception.ERROR_INVALID_CURSOR, nil).WithCause(err)
+		}
+		keyset.cursor = *cursor
+	}
+
+	if keyset.cursor.Direction != dto.PAGE_CURSOR_NEXT && keyset.cursor.Direction != dto.PAGE_CURSOR_PREV {
+		return nil, exception.New(exception.ERROR_INVALID_CURSOR, nil)
+	}
+	if keyset.cursor.SortDirection != "ASC" && keyset.cursor.SortDirection != "DESC" {
+		keyset.cursor.SortDirection = "ASC"
+	}
+
+	keyColumns := strings.Split(keys, ", ")
+	if keyset.cursor.SortBy != "" && !Contains(strings.Split(order, ", "), keyset.cursor.SortBy) {
+		if keyset.start {
+			keyset.cursor.SortBy = ""
+		} else {
+			return nil, exception.New(exception.ERROR_INVALID_CURSOR, nil)
+		}
+	}
+	if keyset.cursor.SortBy != "" && !Contains(keyColumns, keyset.cursor.SortBy) {
+		keyset.Columns = append(keyset.Columns, keyset.cursor.SortBy)
+		keyset.sorted = true
+	}
+	keyset.Columns = append(keyset.Columns, keyColumns...)
+
+	if !keyset.start {
+		if len(keyset.cursor.Values) != len(keyset.Columns) {
+			return nil, exception.New(exception.ERROR_INVALID_CURSOR, nil)
+		}
+		values := keyset.cursor.Values
+		for _, value := range values[len(values)-len(keyColumns):] {
+			if value == nil {
+				return nil, exception.New(exception.ERROR_INVALID_CURSOR, nil)
+			}
+		}
+		if keyset.sorted && values[0] == nil {
+			keyset.sortNull = true
+			values = values[1:]
+		}
+		keyset.Args = append(keyset.Args, values...)
+	}
+	keyset.Args = append(keyset.Args, keyset.PageSize+1)
+
+	return keyset, nil
+}
+
+func (k *Keyset) backward() bool {
+	return k.cursor.Direction == dto.PAGE_CURSOR_PREV
+}
+
+// Query returns the seek condition, order and limit to append after a WHERE
+// clause, e.g. query + keyset.Query(4) with the filters bound to $1..$3.
+func (k *Keyset) Query(argIndex int) string {
+	descending := k.cursor.SortDirection == "DESC"
+	if k.backward() {
+		descending = !descending
+	}
+
+	direction, comparator := " ASC", " > "
+	if descending {
+		direction, comparator = " DESC", " < "
+	}
+
+	// NULL sort values come last in the requested order, so first when reading backward
+	nullsLast := !k.backward()
+
+	keyColumns := k.Columns
+	if k.sorted {
+		keyColumns = k.Columns[1:]
+	}
+
+	query := ""
+	if !k.start {
+		sortPlaceholder := ""
+		if k.sorted && !k.sortNull {
+			sortPlaceholder = "$" + strconv.Itoa(argIndex)
+			argIndex++
+		}
+
+		placeholders := make([]string, len(keyColumns))
+		for i := range keyColumns {
+			placeholders[i] = "$" + strconv.Itoa(argIndex)
+			argIndex++
+		}
+		seek := `(` + strings.Join(keyColumns, ", ") + `)` + comparator + `(` + strings.Join(placeholders, ", ") + `)`
+
+		if k.sorted {
+			column := k.Columns[0]
+			switch {
+			case k.sortNull && nullsLast:
+				seek = column + ` IS NULL AND ` + seek
+			case k.sortNull:
+				seek = column + ` IS NOT NULL OR (` + column + ` IS NULL AND ` + seek + `)`
+			default:
+				seek = column + comparator + sortPlaceholder + ` OR (` + column + ` = ` + sortPlaceholder + ` AND ` + seek + `)`
+				if nullsLast {
+					seek += ` OR ` + column + ` IS NULL`
+				}
+			}
+		}
+		query += ` AND (` + seek + `)`
+	}
+
+	order := make([]string, len(k.Columns))
+	for i, column := range k.Columns {
+		order[i] = column + direction
+	}
+	if k.sorted {
+		if nullsLast {
+			order[0] += " NULLS LAST"
+		} else {
+			order[0] += " NULLS FIRST"
+		}
+	}
+	query += ` ORDER BY ` + strings.Join(order, ", ") + ` LIMIT $` + strconv.Itoa(argIndex) + `::int`
+
+	return query
+}
+
+// RepoPGGetKeysetPage trims the extra row fetched by the keyset query, restores
+// the requested order and fills the cursors of the neighbouring pages.
+func RepoPGGetKeysetPage[T any](keyset *Keyset, rows []T, pageInfo *dto.PageInfo) []T {
+	more := len(rows) > keyset.PageSize
+	if more {
+		rows = rows[:keyset.PageSize]
+	}
+
+	if keyset.backward() {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+		}
+	}
+
+	pageInfo.RowsPerPage = keyset.PageSize
+	pageInfo.NextCursor = ""
+	pageInfo.PrevCursor = ""
+	if len(rows) == 0 {
+		return rows
+	}
+
+	hasNext, hasPrev := more, !keyset.start
+	if keyset.backward() {
+		hasNext, hasPrev = true, more
+	}
+
+	if hasNext {
+		pageInfo.NextCursor = keyset.cursorOf(dto.PAGE_CURSOR_NEXT, rows[len(rows)-1])
+	}
+	if hasPrev {
+		pageInfo.PrevCursor = keyset.cursorOf(dto.PAGE_CURSOR_PREV, rows[0])
+	}
+
+	return rows
+}
+
+func (k *Keyset) cursorOf(direction string, row interface{}) string {
+	value := reflect.Indirect(reflect.ValueOf(row))
+	columns := map[string]interface{}{}
+	for i := 0; i < value.NumField(); i++ {
+		if tagValue, ok := value.Type().Field(i).Tag.Lookup("db"); ok {
+			columns[tagValue] = value.Field(i).Interface()
+		}
+	}
+
+	values := make([]interface{}, len(k.Columns))
+	for i, column := range k.Columns {
+		values[i] = columns[column]
+	}
+
+	cursor := k.cursor
+	cursor.Direction = direction
+	cursor.Values = values
+
+	return cursor.Encode()
+}
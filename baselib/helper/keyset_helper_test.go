@@ -0,0 +1,182 @@
+package helper
+
+import (
+	"encoding/base64"
+	"errors"
+	"gogin-template/baselib/dto"
+	"gogin-template/baselib/exception"
+	"reflect"
+	"testing"
+)
+
+type keysetTestModel struct {
+	HistoryId  string  `db:"history_id" dbx:"key"`
+	SampleName *string `db:"sample_name" dbx:"sort"`
+	Note       string  `db:"note"`
+}
+
+func keysetTestPage(t *testing.T, request dto.PageRequest, rows []keysetTestModel) (*Keyset, dto.PageInfo) {
+	t.Helper()
+
+	keyset, err := RepoPGGetKeyset(reflect.TypeOf(keysetTestModel{}), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	pageInfo := dto.PageInfo{}
+	RepoPGGetKeysetPage(keyset, rows, &pageInfo)
+	return keyset, pageInfo
+}
+
+func TestKeysetQuery(t *testing.T) {
+	name := "b"
+	rows := []keysetTestModel{{HistoryId: "1", SampleName: &name}, {HistoryId: "2"}, {HistoryId: "3"}}
+
+	tests := []struct {
+		name    string
+		request func(t *testing.T) dto.PageRequest
+		query   string
+		args    []interface{}
+	}{
+		{
+			name: "start",
+			request: func(t *testing.T) dto.PageRequest {
+				return dto.PageRequest{Cursor: dto.PAGE_CURSOR_START, PageSize: 2, SortBy: "sample_name"}
+			},
+			query: ` ORDER BY sample_name ASC NULLS LAST, history_id ASC LIMIT $4::int`,
+			args:  []interface{}{3},
+		},
+		{
+			name: "start without sort column",
+			request: func(t *testing.T) dto.PageRequest {
+				return dto.PageRequest{Cursor: dto.PAGE_CURSOR_START, PageSize: 2, SortBy: "note", SortDirection: "DESC"}
+			},
+			query: ` ORDER BY history_id DESC LIMIT $4::int`,
+			args:  []interface{}{3},
+		},
+		{
+			name: "next after a value",
+			request: func(t *testing.T) dto.PageRequest {
+				_, pageInfo := keysetTestPage(t, dto.PageRequest{Cursor: dto.PAGE_CURSOR_START, PageSize: 1, SortBy: "sample_name"}, rows[:2])
+				return dto.PageRequest{Cursor: pageInfo.NextCursor}
+			},
+			query: ` AND (sample_name > $4 OR (sample_name = $4 AND (history_id) > ($5)) OR sample_name IS NULL)` +
+				` ORDER BY sample_name ASC NULLS LAST, history_id ASC LIMIT $6::int`,
+			args: []interface{}{"b", "1", 11},
+		},
+		{
+			name: "next descending after a value",
+			request: func(t *testing.T) dto.PageRequest {
+				_, pageInfo := keysetTestPage(t, dto.PageRequest{Cursor: dto.PAGE_CURSOR_START, PageSize: 1, SortBy: "sample_name", SortDirection: "DESC"}, rows[:2])
+				return dto.PageRequest{Cursor: pageInfo.NextCursor, PageSize: 5}
+			},
+			query: ` AND (sample_name < $4 OR (sample_name = $4 AND (history_id) < ($5)) OR sample_name IS NULL)` +
+				` ORDER BY sample_name DESC NULLS LAST, history_id DESC LIMIT $6::int`,
+			args: []interface{}{"b", "1", 6},
+		},
+		{
+			name: "next after a null",
+			request: func(t *testing.T) dto.PageRequest {
+				_, pageInfo := keysetTestPage(t, dto.PageRequest{Cursor: dto.PAGE_CURSOR_START, PageSize: 2, SortBy: "sample_name"}, rows)
+				return dto.PageRequest{Cursor: pageInfo.NextCursor}
+			},
+			query: ` AND (sample_name IS NULL AND (history_id) > ($4))` +
+				` ORDER BY sample_name ASC NULLS LAST, history_id ASC LIMIT $5::int`,
+			args: []interface{}{"2", 11},
+		},
+		{
+			name: "previous before a null",
+			request: func(t *testing.T) dto.PageRequest {
+				_, next := keysetTestPage(t, dto.PageRequest{Cursor: dto.PAGE_CURSOR_START, PageSize: 2, SortBy: "sample_name"}, rows)
+				_, pageInfo := keysetTestPage(t, dto.PageRequest{Cursor: next.NextCursor}, rows[2:])
+				return dto.PageRequest{Cursor: pageInfo.PrevCursor}
+			},
+			query: ` AND (sample_name IS NOT NULL OR (sample_name IS NULL AND (history_id) < ($4)))` +
+				` ORDER BY sample_name DESC NULLS FIRST, history_id DESC LIMIT $5::int`,
+			args: []interface{}{"3", 11},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			keyset, err := RepoPGGetKeyset(reflect.TypeOf(keysetTestModel{}), test.request(t))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if query := keyset.Query(4); query != test.query {
+				t.Errorf("query = %q, want %q", query, test.query)
+			}
+			if !reflect.DeepEqual(keyset.Args, test.args) {
+				t.Errorf("args = %#v, want %#v", keyset.Args, test.args)
+			}
+		})
+	}
+}
+
+func TestKeysetPage(t *testing.T) {
+	rows := []keysetTestModel{{HistoryId: "1"}, {HistoryId: "2"}, {HistoryId: "3"}}
+
+	keyset, pageInfo := keysetTestPage(t, dto.PageRequest{Cursor: dto.PAGE_CURSOR_START, PageSize: 2}, append([]keysetTestModel{}, rows...))
+	if pageInfo.NextCursor == "" || pageInfo.PrevCursor != "" {
+		t.Fatalf("first page cursors = %+v, want only next", pageInfo)
+	}
+
+	page := RepoPGGetKeysetPage(keyset, append([]keysetTestModel{}, rows...), &dto.PageInfo{})
+	if len(page) != 2 || page[1].HistoryId != "2" {
+		t.Errorf("page = %+v, want the first two rows", page)
+	}
+
+	// Reading backward returns rows in reverse, the page restores the order
+	keyset, err := RepoPGGetKeyset(reflect.TypeOf(keysetTestModel{}), dto.PageRequest{Cursor: dto.Cursor{Direction: dto.PAGE_CURSOR_PREV, SortDirection: "ASC", Values: []interface{}{"3"}}.Encode(), PageSize: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	page = RepoPGGetKeysetPage(keyset, []keysetTestModel{{HistoryId: "2"}, {HistoryId: "1"}}, &pageInfo)
+	if page[0].HistoryId != "1" || pageInfo.NextCursor == "" || pageInfo.PrevCursor != "" {
+		t.Errorf("page = %+v, info = %+v, want rows 1, 2 with only a next cursor", page, pageInfo)
+	}
+}
+
+func TestKeysetCursorRoundTrip(t *testing.T) {
+	x, w := "x", "w"
+	_, pageInfo := keysetTestPage(t, dto.PageRequest{Cursor: dto.PAGE_CURSOR_START, PageSize: 1, SortBy: "SAMPLE_NAME", SortDirection: "DESC"},
+		[]keysetTestModel{{HistoryId: "7", SampleName: &x}, {HistoryId: "8", SampleName: &w}})
+
+	cursor, err := dto.DecodeCursor(pageInfo.NextCursor)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := dto.Cursor{Direction: dto.PAGE_CURSOR_NEXT, SortBy: "sample_name", SortDirection: "DESC", Values: []interface{}{"x", "7"}}
+	if !reflect.DeepEqual(*cursor, want) {
+		t.Errorf("cursor = %+v, want %+v", *cursor, want)
+	}
+}
+
+func TestKeysetRejectsTamperedCursor(t *testing.T) {
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{name: "not base64", cursor: "%%%"},
+		{name: "not json", cursor: base64.RawURLEncoding.EncodeToString([]byte("{"))},
+		{name: "unknown direction", cursor: dto.Cursor{Direction: "up", Values: []interface{}{"1"}}.Encode()},
+		{name: "missing values", cursor: dto.Cursor{Direction: dto.PAGE_CURSOR_NEXT}.Encode()},
+		{name: "extra values", cursor: dto.Cursor{Direction: dto.PAGE_CURSOR_NEXT, Values: []interface{}{"1", "2"}}.Encode()},
+		{name: "null key", cursor: dto.Cursor{Direction: dto.PAGE_CURSOR_NEXT, Values: []interface{}{nil}}.Encode()},
+		{name: "column not sortable", cursor: dto.Cursor{Direction: dto.PAGE_CURSOR_NEXT, SortBy: "note", Values: []interface{}{"x", "1"}}.Encode()},
+		{name: "sql as column", cursor: dto.Cursor{Direction: dto.PAGE_CURSOR_NEXT, SortBy: "1; DROP TABLE x", Values: []interface{}{"x", "1"}}.Encode()},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := RepoPGGetKeyset(reflect.TypeOf(keysetTestModel{}), dto.PageRequest{Cursor: test.cursor})
+
+			var e *exception.ErrorException
+			if !errors.As(err, &e) || e.ErrorCode != exception.ERROR_INVALID_CURSOR {
+				t.Errorf("error = %v, want %s", err, exception.ERROR_INVALID_CURSOR)
+			}
+		})
+	}
+}
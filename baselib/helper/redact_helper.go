@@ -0,0 +1,97 @@
+package helper
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+const REDACTED_VALUE string = "***"
+
+type redactSegment struct {
+	name      string // Object key or array index, "*" matches any
+	recursive bool   // Matches at any depth below the previous segment
+}
+
+// RedactJSON masks the values selected by paths in a JSON document. Paths are
+// a JSONPath subset: "$.user.password", "$.cards[*].number", "$..token", and a
+// bare "password" which matches the key at any depth. Keys match case
+// insensitively. A body that is not valid JSON is returned with ok false.
+func RedactJSON(body []byte, paths []string) (redacted []byte, ok bool) {
+	if len(paths) == 0 {
+		return body, true
+	}
+
+	var document interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return body, false
+	}
+
+	for _, path := range paths {
+		document = redactNode(document, parseRedactPath(path))
+	}
+
+	redacted, err := json.Marshal(document)
+	if err != nil {
+		return body, false
+	}
+
+	return redacted, true
+}
+
+func parseRedactPath(path string) []redactSegment {
+	path = strings.TrimSpace(path)
+	if !strings.HasPrefix(path, "$") {
+		path = "$.." + path
+	}
+	path = strings.NewReplacer("[", ".", "]", "").Replace(strings.TrimPrefix(path, "$"))
+
+	segments := []redactSegment{}
+	recursive := false
+	for i, part := range strings.Split(path, ".") {
+		// An empty part comes from "..", apart from the leading separator.
+		if part == "" {
+			recursive = recursive || i > 0
+			continue
+		}
+		segments = append(segments, redactSegment{name: strings.Trim(part, `'"`), recursive: recursive})
+		recursive = false
+	}
+
+	return segments
+}
+
+func (s redactSegment) matches(key string) bool {
+	return s.name == "*" || strings.EqualFold(s.name, key)
+}
+
+func redactNode(node interface{}, segments []redactSegment) interface{} {
+	if len(segments) == 0 {
+		return REDACTED_VALUE
+	}
+	segment := segments[0]
+
+	switch value := node.(type) {
+	case map[string]interface{}:
+		for key, child := range value {
+			if segment.matches(key) {
+				value[key] = redactNode(child, segments[1:])
+			} else if segment.recursive {
+				value[key] = redactNode(child, segments)
+			}
+		}
+	case []interface{}:
+		for i, child := range value {
+			if !segment.recursive && segment.matches(strconv.Itoa(i)) {
+				value[i] = redactNode(child, segments[1:])
+			} else if segment.recursive {
+				value[i] = redactNode(child, segments)
+			}
+		}
+	}
+
+	return node
+}
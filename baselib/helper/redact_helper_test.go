@@ -0,0 +1,108 @@
+package helper
+
+import "testing"
+
+func TestRedactJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		body  string
+		paths []string
+		want  string
+	}{
+		{
+			name:  "no paths",
+			body:  `{"password":"x"}`,
+			paths: nil,
+			want:  `{"password":"x"}`,
+		},
+		{
+			name:  "absolute path",
+			body:  `{"user":{"name":"a","password":"x"},"password":"y"}`,
+			paths: []string{"$.user.password"},
+			want:  `{"password":"y","user":{"name":"a","password":"***"}}`,
+		},
+		{
+			name:  "bare name at any depth",
+			body:  `{"password":"x","user":{"password":"y"},"list":[{"password":"z"}]}`,
+			paths: []string{"password"},
+			want:  `{"list":[{"password":"***"}],"password":"***","user":{"password":"***"}}`,
+		},
+		{
+			name:  "recursive descent",
+			body:  `{"auth":{"token":"x"},"items":[{"token":"y"}]}`,
+			paths: []string{"$..token"},
+			want:  `{"auth":{"token":"***"},"items":[{"token":"***"}]}`,
+		},
+		{
+			name:  "every array element",
+			body:  `{"cards":[{"number":"1","type":"a"},{"number":"2","type":"b"}]}`,
+			paths: []string{"$.cards[*].number"},
+			want:  `{"cards":[{"number":"***","type":"a"},{"number":"***","type":"b"}]}`,
+		},
+		{
+			name:  "array index",
+			body:  `{"cards":[{"number":"1"},{"number":"2"}]}`,
+			paths: []string{"$.cards[1].number"},
+			want:  `{"cards":[{"number":"1"},{"number":"***"}]}`,
+		},
+		{
+			name:  "whole subtree",
+			body:  `{"card":{"number":"1","cvv":"2"},"id":1}`,
+			paths: []string{"$.card"},
+			want:  `{"card":"***","id":1}`,
+		},
+		{
+			name:  "keys match case insensitively",
+			body:  `{"User":{"PassWord":"x"}}`,
+			paths: []string{"$.user.password"},
+			want:  `{"User":{"PassWord":"***"}}`,
+		},
+		{
+			name:  "quoted bracket key",
+			body:  `{"user":{"password":"x"}}`,
+			paths: []string{"$['user']['password']"},
+			want:  `{"user":{"password":"***"}}`,
+		},
+		{
+			name:  "missing path leaves the body",
+			body:  `{"user":{"name":"a"},"count":12345678901234567890}`,
+			paths: []string{"$.user.password", "$.other[0]"},
+			want:  `{"count":12345678901234567890,"user":{"name":"a"}}`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			redacted, ok := RedactJSON([]byte(test.body), test.paths)
+			if !ok {
+				t.Fatalf("ok = false, want true")
+			}
+			if string(redacted) != test.want {
+				t.Errorf("redacted = %s, want %s", redacted, test.want)
+			}
+		})
+	}
+}
+
+func TestRedactJSONRejectsInvalidBody(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+	}{
+		{name: "empty", body: ""},
+		{name: "truncated", body: `{"password":"x"`},
+		{name: "form body", body: "password=x&user=a"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			redacted, ok := RedactJSON([]byte(test.body), []string{"password"})
+			if ok {
+				t.Errorf("ok = true, want false")
+			}
+			if string(redacted) != test.body {
+				t.Errorf("redacted = %s, want the body unchanged", redacted)
+			}
+		})
+	}
+}
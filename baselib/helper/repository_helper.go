@@ -1,11 +1,16 @@
 package helper
 
 import (
+	"errors"
 	"reflect"
 	"strconv"
 	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+const PG_UNIQUE_VIOLATION string = "23505"
+
 func RepoPGGetColumns(typ reflect.Type) (key string, insert string, update string, field string, order string) {
 	key, insert, update, field, order = "", "", "", "", ""
 	for i := 0; i < typ.NumField(); i++ {
@@ -142,3 +147,10 @@ func GetLimitAndOffset(pageSize int, page int) (*int, *int) {
 
 	return limit, offset
 }
+
+// RepoPGIsUniqueViolation reports whether err violates the unique constraint or
+// unique index named constraint.
+func RepoPGIsUniqueViolation(err error, constraint string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == PG_UNIQUE_VIOLATION && pgErr.ConstraintName == constraint
+}
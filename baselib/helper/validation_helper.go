@@ -0,0 +1,67 @@
+package helper
+
+import (
+	"context"
+	"errors"
+	"gogin-template/baselib/dto"
+	"gogin-template/baselib/exception"
+	"gogin-template/baselib/identifier"
+	"gogin-template/bootstrap"
+	"strings"
+	"unicode"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// ValidateStruct runs the validate tags of obj, failures are returned as a
+// validation exception listing every field with its translated message.
+func ValidateStruct(c context.Context, cfg *bootstrap.Container, obj any) error {
+	err := cfg.Validator().Struct(obj)
+	if err == nil {
+		return nil
+	}
+
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return err
+	}
+
+	translator := cfg.Translator(identifier.GetLocale(c))
+	fieldErrors := make([]dto.FieldError, len(validationErrors))
+	for i, fe := range validationErrors {
+		field := validationFieldPath(fe.Namespace())
+		fieldErrors[i] = dto.FieldError{
+			Field:   field,
+			Rule:    fe.Tag(),
+			Param:   fe.Param(),
+			Message: strings.Replace(fe.Translate(translator), fe.Field(), lowerFirst(fe.Field()), 1),
+		}
+	}
+
+	return exception.New(exception.ERROR_VALIDATION_FAILED, nil).WithErrors(fieldErrors)
+}
+
+// validationFieldPath turns "SampleModel.SampleVersions[0].VersionNumber" into
+// "sampleVersions[0].versionNumber" to match the JSON names of the API.
+func validationFieldPath(namespace string) string {
+	parts := strings.Split(namespace, ".")
+	if len(parts) > 1 {
+		parts = parts[1:]
+	}
+
+	for i := range parts {
+		parts[i] = lowerFirst(parts[i])
+	}
+
+	return strings.Join(parts, ".")
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	runes := []rune(s)
+	runes[0] = unicode.ToLower(runes[0])
+	return string(runes)
+}
@@ -0,0 +1,38 @@
+package identifier
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+type ClaimsCtxKey struct{}
+
+type Claims struct {
+	jwt.RegisteredClaims
+	Name  string   `json:"name,omitempty"`
+	Roles []string `json:"roles,omitempty"`
+	Scope string   `json:"scope,omitempty"`
+}
+
+func GetClaims(c context.Context) *Claims {
+	claims, _ := c.Value(ClaimsCtxKey{}).(*Claims)
+	return claims
+}
+
+func SetClaims(c *gin.Context, claims *Claims) {
+	nctx := context.WithValue(c.Request.Context(), ClaimsCtxKey{}, claims)
+	c.Request = c.Request.WithContext(nctx)
+}
+
+// GetSubject returns the authenticated user id, or an empty string when the
+// request did not go through AuthMiddleware.
+func GetSubject(c context.Context) string {
+	claims := GetClaims(c)
+	if claims == nil {
+		return ""
+	}
+
+	return claims.Subject
+}
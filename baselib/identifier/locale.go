@@ -0,0 +1,27 @@
+package identifier
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+const DEFAULT_LOCALE string = "en"
+
+type LocaleCtxKey struct{}
+
+// GetLocale returns the language negotiated for the request, english when none was set.
+func GetLocale(ctx context.Context) string {
+	locale, _ := ctx.Value(LocaleCtxKey{}).(string)
+
+	if locale == "" {
+		locale = DEFAULT_LOCALE
+	}
+
+	return locale
+}
+
+func SetLocale(c *gin.Context, locale string) {
+	nctx := context.WithValue(c.Request.Context(), LocaleCtxKey{}, locale)
+	c.Request = c.Request.WithContext(nctx)
+}
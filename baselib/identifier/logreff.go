@@ -12,8 +12,10 @@ import (
 
 type LogReffCtxKey struct{}
 
+// GetLogReff returns the log reference of the request, a random one when
+// CorrelationMiddleware did not set it.
 func GetLogReff(c *gin.Context) string {
-	logReff, _ := c.Request.Context().Value(LogReffCtxKey{}).(string)
+	logReff, _ := LookupLogReff(c.Request.Context())
 
 	if logReff == "" {
 		logReff = fmt.Sprint(uuid.New())
@@ -22,6 +24,12 @@ func GetLogReff(c *gin.Context) string {
 	return logReff
 }
 
+// LookupLogReff returns the log reference stored in ctx, if any.
+func LookupLogReff(ctx context.Context) (string, bool) {
+	logReff, ok := ctx.Value(LogReffCtxKey{}).(string)
+	return logReff, ok && logReff != ""
+}
+
 func SetLogReff(c *gin.Context, logReff string) {
 	nctx := context.WithValue(c.Request.Context(), LogReffCtxKey{}, logReff)
 	c.Request = c.Request.WithContext(nctx)
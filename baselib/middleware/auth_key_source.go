@@ -0,0 +1,309 @@
+package middleware
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"gogin-template/bootstrap"
+	"math/big"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// KeySource resolves the verification key for a parsed, not yet verified token.
+type KeySource interface {
+	Key(token *jwt.Token) (interface{}, error)
+}
+
+type verificationKey struct {
+	alg string
+	key interface{}
+}
+
+// NewKeySource builds the key source described by auth.jwt: static keys from
+// auth.jwt.keys and, when auth.jwt.jwks.path is set, a JWKS file reloaded on change.
+func NewKeySource(cfg *bootstrap.Container) KeySource {
+	sources := multiKeySource{}
+
+	configSource, err := NewConfigKeySource(cfg)
+	if err != nil {
+		cfg.Logger().Fatal(err)
+	}
+	sources = append(sources, configSource)
+
+	if path := cfg.Config().Auth.Jwt.Jwks.Path; path != "" {
+		refresh := time.Duration(cfg.Config().Auth.Jwt.Jwks.Refresh) * time.Second
+		jwksSource, err := NewJWKSKeySource(path, refresh)
+		if err != nil {
+			cfg.Logger().Fatal(err)
+		}
+		sources = append(sources, jwksSource)
+	}
+
+	return sources
+}
+
+type multiKeySource []KeySource
+
+func (m multiKeySource) Key(token *jwt.Token) (interface{}, error) {
+	var lastErr error
+	for _, source := range m {
+		key, err := source.Key(token)
+		if err == nil {
+			return key, nil
+		}
+		lastErr = err
+	}
+
+	if lastErr == nil {
+		lastErr = fmt.Errorf("no verification key configured")
+	}
+
+	return nil, lastErr
+}
+
+type staticKeySource struct {
+	keys map[string]verificationKey
+}
+
+func NewConfigKeySource(cfg *bootstrap.Container) (KeySource, error) {
+	keys := map[string]verificationKey{}
+	for _, entry := range cfg.Config().Auth.Jwt.Keys {
+		key, err := parseConfigKey(entry)
+		if err != nil {
+			return nil, fmt.Errorf("auth.jwt.keys[%s]: %w", entry.Kid, err)
+		}
+		keys[entry.Kid] = *key
+	}
+
+	return &staticKeySource{keys: keys}, nil
+}
+
+func (s *staticKeySource) Key(token *jwt.Token) (interface{}, error) {
+	return lookupKey(s.keys, token)
+}
+
+type jwksKeySource struct {
+	path      string
+	refresh   time.Duration
+	mu        sync.RWMutex
+	keys      map[string]verificationKey
+	modTime   time.Time
+	checkedAt time.Time
+}
+
+// NewJWKSKeySource reads a local JWKS file and re-reads it whenever its
+// modification time changes, checked at most once per refresh interval or
+// right away when a token carries an unknown kid.
+func NewJWKSKeySource(path string, refresh time.Duration) (KeySource, error) {
+	if refresh <= 0 {
+		refresh = 5 * time.Minute
+	}
+
+	s := &jwksKeySource{path: path, refresh: refresh}
+	if err := s.reload(true); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *jwksKeySource) Key(token *jwt.Token) (interface{}, error) {
+	s.mu.RLock()
+	stale := time.Since(s.checkedAt) > s.refresh
+	s.mu.RUnlock()
+
+	if stale {
+		s.reload(false)
+	}
+
+	s.mu.RLock()
+	key, err := lookupKey(s.keys, token)
+	s.mu.RUnlock()
+
+	if err != nil && !stale {
+		// The key may have been rotated in since the last check
+		s.mu.RLock()
+		recent := time.Since(s.checkedAt) < time.Second
+		s.mu.RUnlock()
+
+		if !recent && s.reload(false) == nil {
+			s.mu.RLock()
+			key, err = lookupKey(s.keys, token)
+			s.mu.RUnlock()
+		}
+	}
+
+	return key, err
+}
+
+func (s *jwksKeySource) reload(force bool) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkedAt = time.Now()
+
+	info, err := os.Stat(s.path)
+	if err != nil {
+		return err
+	}
+
+	if !force && info.ModTime().Equal(s.modTime) {
+		return nil
+	}
+
+	content, err := os.ReadFile(s.path)
+	if err != nil {
+		return err
+	}
+
+	keys, err := parseJWKS(content)
+	if err != nil {
+		return err
+	}
+
+	s.keys = keys
+	s.modTime = info.ModTime()
+
+	return nil
+}
+
+func lookupKey(keys map[string]verificationKey, token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, ok := keys[kid]
+	if !ok && kid == "" && len(keys) == 1 {
+		for _, only := range keys {
+			key, ok = only, true
+		}
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+
+	// Never let the token choose a different algorithm than the key was issued for
+	if key.alg != token.Method.Alg() {
+		return nil, fmt.Errorf("key %q does not accept algorithm %s", kid, token.Method.Alg())
+	}
+
+	return key.key, nil
+}
+
+func parseConfigKey(entry bootstrap.JwtKeyConfig) (*verificationKey, error) {
+	switch entry.Alg {
+	case jwt.SigningMethodHS256.Alg():
+		if entry.Secret == "" {
+			return nil, fmt.Errorf("secret is required for %s", entry.Alg)
+		}
+		return &verificationKey{alg: entry.Alg, key: []byte(entry.Secret)}, nil
+	case jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg():
+		pem := []byte(entry.PublicKey)
+		if entry.PublicKeyFile != "" {
+			var err error
+			pem, err = os.ReadFile(entry.PublicKeyFile)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		if entry.Alg == jwt.SigningMethodRS256.Alg() {
+			key, err := jwt.ParseRSAPublicKeyFromPEM(pem)
+			if err != nil {
+				return nil, err
+			}
+			return &verificationKey{alg: entry.Alg, key: key}, nil
+		}
+
+		key, err := jwt.ParseECPublicKeyFromPEM(pem)
+		if err != nil {
+			return nil, err
+		}
+		return &verificationKey{alg: entry.Alg, key: key}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported algorithm %q", entry.Alg)
+}
+
+type jwk struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	K   string `json:"k"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func parseJWKS(content []byte) (map[string]verificationKey, error) {
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+	if err := json.Unmarshal(content, &set); err != nil {
+		return nil, err
+	}
+
+	keys := map[string]verificationKey{}
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := parseJWK(k)
+		if err != nil {
+			return nil, fmt.Errorf("jwks key %q: %w", k.Kid, err)
+		}
+		keys[k.Kid] = *key
+	}
+
+	return keys, nil
+}
+
+func parseJWK(k jwk) (*verificationKey, error) {
+	decode := base64.RawURLEncoding.DecodeString
+
+	switch k.Kty {
+	case "oct":
+		secret, err := decode(k.K)
+		if err != nil {
+			return nil, err
+		}
+		return &verificationKey{alg: jwt.SigningMethodHS256.Alg(), key: secret}, nil
+	case "RSA":
+		n, err := decode(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := decode(k.E)
+		if err != nil {
+			return nil, err
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		return &verificationKey{alg: jwt.SigningMethodRS256.Alg(), key: key}, nil
+	case "EC":
+		if k.Crv != "P-256" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := decode(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := decode(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		return &verificationKey{alg: jwt.SigningMethodES256.Alg(), key: key}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+}
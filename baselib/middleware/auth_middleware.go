@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"gogin-template/baselib/exception"
+	"gogin-template/baselib/identifier"
+	"gogin-template/bootstrap"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func AuthMiddleware(cfg *bootstrap.Container, keys KeySource) gin.HandlerFunc {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{
+			jwt.SigningMethodHS256.Alg(),
+			jwt.SigningMethodRS256.Alg(),
+			jwt.SigningMethodES256.Alg(),
+		}),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(time.Duration(cfg.Config().Auth.Jwt.Leeway) * time.Second),
+	}
+	if issuer := cfg.Config().Auth.Jwt.Issuer; issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
+	}
+	if audience := cfg.Config().Auth.Jwt.Audience; audience != "" {
+		options = append(options, jwt.WithAudience(audience))
+	}
+	parser := jwt.NewParser(options...)
+
+	return func(c *gin.Context) {
+		header := c.GetHeader("Authorization")
+		tokenString, found := strings.CutPrefix(header, "Bearer ")
+		if !found || strings.TrimSpace(tokenString) == "" {
+			c.Error(exception.New(exception.ERROR_MISSING_TOKEN, nil))
+			c.Abort()
+			return
+		}
+
+		claims := &identifier.Claims{}
+		_, err := parser.ParseWithClaims(strings.TrimSpace(tokenString), claims, keys.Key)
+		if err != nil {
+			cfg.Log(c).Debugf("rejected bearer token: %s", err)
+			c.Error(exception.New(exception.ERROR_INVALID_TOKEN, nil))
+			c.Abort()
+			return
+		}
+
+		if claims.Subject == "" {
+			c.Error(exception.New(exception.ERROR_TOKEN_WITHOUT_SUBJECT, nil))
+			c.Abort()
+			return
+		}
+
+		identifier.SetClaims(c, claims)
+		c.Request = c.Request.WithContext(bootstrap.ContextWithLogger(c.Request.Context(), cfg.Log(c).WithField("userId", claims.Subject)))
+		c.Next()
+	}
+}
@@ -0,0 +1,251 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"gogin-template/baselib/exception"
+	"gogin-template/baselib/identifier"
+	"gogin-template/bootstrap"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func authTestToken(t *testing.T, method jwt.SigningMethod, kid string, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+
+	return signed
+}
+
+// authTestRequest runs a request through AuthMiddleware and returns the status
+// of the error it raised, or 200 with the subject the handler saw.
+func authTestRequest(cfg *bootstrap.Container, keys KeySource, header string) (int, string) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Next()
+
+		var e *exception.ErrorException
+		if last := c.Errors.Last(); last != nil && errors.As(last.Err, &e) {
+			c.Status(e.HttpStatusCode)
+		}
+	})
+	engine.GET("/", AuthMiddleware(cfg, keys), func(c *gin.Context) {
+		c.String(http.StatusOK, identifier.GetSubject(c.Request.Context()))
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if header != "" {
+		req.Header.Set("Authorization", header)
+	}
+	res := httptest.NewRecorder()
+	engine.ServeHTTP(res, req)
+
+	return res.Code, res.Body.String()
+}
+
+func TestAuthMiddleware(t *testing.T) {
+	cfg := bootstrap.Init()
+
+	secret := []byte("a-secret-that-is-long-enough-for-hs256")
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	public, err := x509.MarshalPKIXPublicKey(&private.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	publicPem := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: public})
+
+	keys := &staticKeySource{keys: map[string]verificationKey{
+		"hs": {alg: jwt.SigningMethodHS256.Alg(), key: secret},
+		"rs": {alg: jwt.SigningMethodRS256.Alg(), key: &private.PublicKey},
+	}}
+
+	valid := func() jwt.MapClaims {
+		return jwt.MapClaims{"sub": "user-1", "exp": time.Now().Add(time.Minute).Unix()}
+	}
+
+	tests := []struct {
+		name    string
+		header  string
+		status  int
+		subject string
+	}{
+		{
+			name:    "hmac key",
+			header:  "Bearer " + authTestToken(t, jwt.SigningMethodHS256, "hs", secret, valid()),
+			status:  http.StatusOK,
+			subject: "user-1",
+		},
+		{
+			name:    "rsa key",
+			header:  "Bearer " + authTestToken(t, jwt.SigningMethodRS256, "rs", private, valid()),
+			status:  http.StatusOK,
+			subject: "user-1",
+		},
+		{
+			name:   "missing header",
+			header: "",
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "not a bearer token",
+			header: "Basic dXNlcjpwYXNz",
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "unknown kid",
+			header: "Bearer " + authTestToken(t, jwt.SigningMethodHS256, "other", secret, valid()),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "no kid with several keys",
+			header: "Bearer " + authTestToken(t, jwt.SigningMethodHS256, "", secret, valid()),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "hmac signed with the rsa public key",
+			header: "Bearer " + authTestToken(t, jwt.SigningMethodHS256, "rs", publicPem, valid()),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "rsa token for the hmac key",
+			header: "Bearer " + authTestToken(t, jwt.SigningMethodRS256, "hs", private, valid()),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "algorithm none",
+			header: "Bearer " + authTestToken(t, jwt.SigningMethodNone, "hs", jwt.UnsafeAllowNoneSignatureType, valid()),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "algorithm outside the allowed list",
+			header: "Bearer " + authTestToken(t, jwt.SigningMethodHS512, "hs", secret, valid()),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "expired",
+			header: "Bearer " + authTestToken(t, jwt.SigningMethodHS256, "hs", secret, jwt.MapClaims{"sub": "user-1", "exp": time.Now().Add(-time.Minute).Unix()}),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "without expiry",
+			header: "Bearer " + authTestToken(t, jwt.SigningMethodHS256, "hs", secret, jwt.MapClaims{"sub": "user-1"}),
+			status: http.StatusUnauthorized,
+		},
+		{
+			name:   "without subject",
+			header: "Bearer " + authTestToken(t, jwt.SigningMethodHS256, "hs", secret, jwt.MapClaims{"exp": time.Now().Add(time.Minute).Unix()}),
+			status: http.StatusUnauthorized,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, subject := authTestRequest(cfg, keys, test.header)
+			if status != test.status {
+				t.Errorf("status = %d, want %d", status, test.status)
+			}
+			if subject != test.subject {
+				t.Errorf("subject = %q, want %q", subject, test.subject)
+			}
+		})
+	}
+}
+
+func TestJWKSKeySource(t *testing.T) {
+	secret := []byte("a-secret-that-is-long-enough-for-hs256")
+	private, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	encode := base64.RawURLEncoding.EncodeToString
+	writeJWKS := func(t *testing.T, path string, modTime time.Time, keys ...jwk) {
+		t.Helper()
+
+		content, _ := json.Marshal(map[string][]jwk{"keys": keys})
+		if err := os.WriteFile(path, content, 0o600); err != nil {
+			t.Fatalf("failed to write jwks: %v", err)
+		}
+		if err := os.Chtimes(path, modTime, modTime); err != nil {
+			t.Fatalf("failed to touch jwks: %v", err)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	writeJWKS(t, path, time.Now().Add(-time.Hour),
+		jwk{Kid: "oct-1", Kty: "oct", K: encode(secret)},
+		jwk{Kid: "rsa-1", Kty: "RSA", N: encode(private.N.Bytes()), E: encode(big.NewInt(int64(private.E)).Bytes())},
+		jwk{Kid: "enc-1", Kty: "oct", Use: "enc", K: encode(secret)},
+	)
+
+	source, err := NewJWKSKeySource(path, time.Hour)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	parse := func(token string) error {
+		_, err := jwt.Parse(token, source.Key, jwt.WithValidMethods([]string{"HS256", "RS256", "ES256"}))
+		return err
+	}
+	claims := jwt.MapClaims{"sub": "user-1", "exp": time.Now().Add(time.Minute).Unix()}
+
+	tests := []struct {
+		name  string
+		token string
+		valid bool
+	}{
+		{name: "oct key", token: authTestToken(t, jwt.SigningMethodHS256, "oct-1", secret, claims), valid: true},
+		{name: "rsa key", token: authTestToken(t, jwt.SigningMethodRS256, "rsa-1", private, claims), valid: true},
+		{name: "encryption key is skipped", token: authTestToken(t, jwt.SigningMethodHS256, "enc-1", secret, claims), valid: false},
+		{name: "hmac token for the rsa key", token: authTestToken(t, jwt.SigningMethodHS256, "rsa-1", secret, claims), valid: false},
+		{name: "unknown kid", token: authTestToken(t, jwt.SigningMethodHS256, "oct-2", secret, claims), valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := parse(test.token); (err == nil) != test.valid {
+				t.Errorf("error = %v, want valid %v", err, test.valid)
+			}
+		})
+	}
+
+	t.Run("rotated key", func(t *testing.T) {
+		rotated := []byte("another-secret-that-is-long-enough-too")
+		writeJWKS(t, path, time.Now(), jwk{Kid: "oct-2", Kty: "oct", K: encode(rotated)})
+		// An unknown kid re-reads the file unless it was checked within the last second
+		source.(*jwksKeySource).checkedAt = time.Now().Add(-2 * time.Second)
+
+		if err := parse(authTestToken(t, jwt.SigningMethodHS256, "oct-2", rotated, claims)); err != nil {
+			t.Errorf("error = %v, want the rotated key to be found", err)
+		}
+		if err := parse(authTestToken(t, jwt.SigningMethodHS256, "oct-1", secret, claims)); err == nil {
+			t.Errorf("error = nil, want the removed key to be rejected")
+		}
+	})
+}
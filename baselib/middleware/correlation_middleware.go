@@ -0,0 +1,46 @@
+package middleware
+
+import (
+	"gogin-template/baselib/identifier"
+	"gogin-template/bootstrap"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Incoming ids end up in logs and headers, anything else is replaced.
+var correlationIdPattern = regexp.MustCompile(`^[A-Za-z0-9._:\-]{1,128}$`)
+
+// CorrelationMiddleware takes the log reference from the first of the
+// server.correlation.headers the request carries, or generates one, and echoes
+// it in those response headers. HttpClient forwards it on outbound calls.
+func CorrelationMiddleware(cfg *bootstrap.Container) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		headers := cfg.Config().Server.Correlation.Headers
+
+		logReff := ""
+		for _, header := range headers {
+			if value := strings.TrimSpace(c.GetHeader(header)); correlationIdPattern.MatchString(value) {
+				logReff = value
+				break
+			}
+		}
+
+		if logReff == "" {
+			identifier.SetLogReffWithRandom(c)
+		} else {
+			identifier.SetLogReff(c, logReff)
+		}
+		logReff = identifier.GetLogReff(c)
+
+		trace.SpanFromContext(c.Request.Context()).SetAttributes(attribute.String("log.reff", logReff))
+		for _, header := range headers {
+			c.Header(header, logReff)
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"gogin-template/baselib/identifier"
+	"gogin-template/bootstrap"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestCorrelationMiddleware(t *testing.T) {
+	// The default server.correlation.headers are X-Request-ID and X-Log-Reff
+	cfg := bootstrap.Init()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name    string
+		headers map[string]string
+		logReff string // Empty when a generated one is expected
+	}{
+		{name: "request id", headers: map[string]string{"X-Request-ID": "req-1"}, logReff: "req-1"},
+		{name: "log reff", headers: map[string]string{"X-Log-Reff": "abc.def:12"}, logReff: "abc.def:12"},
+		{name: "first configured header wins", headers: map[string]string{"X-Log-Reff": "second", "X-Request-ID": "first"}, logReff: "first"},
+		{name: "surrounding spaces are trimmed", headers: map[string]string{"X-Request-ID": "  req-2 "}, logReff: "req-2"},
+		{name: "invalid value falls through", headers: map[string]string{"X-Request-ID": "bad value", "X-Log-Reff": "good"}, logReff: "good"},
+		{name: "header injection is rejected", headers: map[string]string{"X-Request-ID": "a\r\nSet-Cookie: x=1"}},
+		{name: "too long is rejected", headers: map[string]string{"X-Request-ID": strings.Repeat("a", 129)}},
+		{name: "missing", headers: nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var seen string
+			engine := gin.New()
+			engine.Use(CorrelationMiddleware(cfg))
+			engine.GET("/", func(c *gin.Context) {
+				seen = identifier.GetLogReff(c)
+				c.Status(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			for header, value := range test.headers {
+				req.Header.Set(header, value)
+			}
+			res := httptest.NewRecorder()
+			engine.ServeHTTP(res, req)
+
+			if test.logReff != "" && seen != test.logReff {
+				t.Errorf("logReff = %q, want %q", seen, test.logReff)
+			}
+			if test.logReff == "" {
+				if _, err := uuid.Parse(seen); err != nil {
+					t.Errorf("logReff = %q, want a generated uuid", seen)
+				}
+			}
+
+			for _, header := range []string{"X-Request-ID", "X-Log-Reff"} {
+				if echoed := res.Header().Get(header); echoed != seen {
+					t.Errorf("%s = %q, want %q echoed", header, echoed, seen)
+				}
+			}
+		})
+	}
+}
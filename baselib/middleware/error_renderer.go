@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"gogin-template/baselib/dto"
+	"gogin-template/baselib/exception"
+	"gogin-template/baselib/identifier"
+	"gogin-template/bootstrap"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	ERROR_FORMAT_ENVELOPE string = "envelope"
+	ERROR_FORMAT_PROBLEM  string = "problem"
+)
+
+// ErrorRenderer writes e as the response of c and aborts the chain.
+type ErrorRenderer func(c *gin.Context, e *exception.ErrorException)
+
+// NewErrorRenderer renders errors in the server.error.format of the config,
+// a client sending "Accept: application/problem+json" always gets a problem.
+func NewErrorRenderer(cfg *bootstrap.Container) ErrorRenderer {
+	format := strings.ToLower(cfg.Config().Server.Error.Format)
+	problem := ProblemErrorRenderer(cfg.Config().Server.Error.TypeBase)
+
+	return func(c *gin.Context, e *exception.ErrorException) {
+		if format == ERROR_FORMAT_PROBLEM || strings.Contains(c.GetHeader("Accept"), dto.PROBLEM_CONTENT_TYPE) {
+			problem(c, e)
+			return
+		}
+
+		EnvelopeErrorRenderer(c, e)
+	}
+}
+
+// EnvelopeErrorRenderer writes the standard dto.Response envelope.
+func EnvelopeErrorRenderer(c *gin.Context, e *exception.ErrorException) {
+	resp := dto.Response[any]{
+		LogReff:         identifier.GetLogReff(c),
+		TraceId:         identifier.GetTraceId(c),
+		ResponseCode:    e.ErrorCode,
+		ResponseMessage: e.ErrorMessage,
+		Errors:          e.Errors,
+	}
+	c.AbortWithStatusJSON(e.HttpStatusCode, resp)
+}
+
+// ProblemErrorRenderer writes an RFC 7807 problem, the type is typeBase plus
+// the error code, or about:blank when no base is configured.
+func ProblemErrorRenderer(typeBase string) ErrorRenderer {
+	return func(c *gin.Context, e *exception.ErrorException) {
+		problemType := "about:blank"
+		if typeBase != "" {
+			problemType = strings.TrimSuffix(typeBase, "/") + "/" + e.ErrorCode
+		}
+
+		problem := dto.Problem{
+			Type:     problemType,
+			Title:    http.StatusText(e.HttpStatusCode),
+			Status:   e.HttpStatusCode,
+			Detail:   e.ErrorMessage,
+			Instance: c.Request.URL.RequestURI(),
+			Code:     e.ErrorCode,
+			LogReff:  identifier.GetLogReff(c),
+			TraceId:  identifier.GetTraceId(c),
+			Errors:   e.Errors,
+		}
+
+		// The JSON render keeps a Content-Type that is already set.
+		c.Header("Content-Type", dto.PROBLEM_CONTENT_TYPE)
+		c.AbortWithStatusJSON(e.HttpStatusCode, problem)
+	}
+}
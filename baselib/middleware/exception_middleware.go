@@ -1,45 +1,52 @@
 package middleware
 
 import (
-	"fmt"
-	"gogin-template/baselib/dto"
+	"errors"
 	"gogin-template/baselib/exception"
 	"gogin-template/baselib/identifier"
 	"gogin-template/bootstrap"
-	"net/http"
-	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"golang.org/x/text/language"
 )
 
 func ExceptionMiddleware(cfg *bootstrap.Container) gin.HandlerFunc {
+	render := NewErrorRenderer(cfg)
+
+	if err := exception.LoadTranslations(cfg.Config().I18n.Dir); err != nil {
+		cfg.Logger().Error(err)
+	}
+
+	locales := exception.Locales()
+	tags := make([]language.Tag, len(locales))
+	for i, locale := range locales {
+		tags[i] = language.Make(locale)
+	}
+	matcher := language.NewMatcher(tags)
+
 	return func(c *gin.Context) {
+		// Negotiate before the handlers run so validation messages use it too.
+		locale := identifier.DEFAULT_LOCALE
+		if accept := c.GetHeader("Accept-Language"); accept != "" {
+			_, index := language.MatchStrings(matcher, accept)
+			locale = locales[index]
+		}
+		identifier.SetLocale(c, locale)
+
 		c.Next()
 		if len(c.Errors) > 0 {
 			err := c.Errors.Last().Err
-			code := strconv.Itoa(http.StatusInternalServerError)
-			message := "Internal Server Error"
-			httpStatus := http.StatusInternalServerError
-			traceId := identifier.GetTraceId(c)
-			logReff := identifier.GetLogReff(c)
-
-			switch e := err.(type) {
-			case *exception.ErrorException:
-				code = e.ErrorCode
-				message = e.ErrorMessage
-				httpStatus = e.HttpStatusCode
-			default:
+
+			var e *exception.ErrorException
+			if !errors.As(err, &e) {
+				e = exception.New(exception.ERROR_INTERNAL, nil)
 			}
 
-			cfg.Logger().Errorf("%s - %s - %s", code, message, err)
+			cfg.Log(c).Errorf("%s - %s - %s", e.ErrorCode, e.ErrorMessage, err)
 
-			resp := dto.Response[any]{
-				LogReff:         logReff,
-				TraceId:         fmt.Sprint(traceId),
-				ResponseCode:    code,
-				ResponseMessage: message,
-			}
-			c.AbortWithStatusJSON(httpStatus, resp)
+			localized := *e
+			localized.ErrorMessage = e.Localize(locale)
+			render(c, &localized)
 		}
 	}
 }
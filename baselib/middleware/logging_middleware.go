@@ -3,30 +3,59 @@ package middleware
 import (
 	"bufio"
 	"bytes"
+	"fmt"
+	"gogin-template/baselib/helper"
+	"gogin-template/baselib/identifier"
 	"gogin-template/bootstrap"
 	"io"
+	"mime"
 	"net"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
 )
 
+// cappedBuffer keeps the first limit bytes written to it and counts the rest.
+type cappedBuffer struct {
+	limit int
+	size  int
+	body  bytes.Buffer
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	b.size += len(p)
+	if remaining := b.limit - b.body.Len(); remaining > 0 {
+		b.body.Write(p[:min(len(p), remaining)])
+	}
+	return len(p), nil
+}
+
+func (b *cappedBuffer) truncated() bool {
+	return b.size > b.body.Len()
+}
+
+type requestBodyLogger struct {
+	io.Reader
+	io.Closer
+}
+
 type responseBodyLogger struct {
 	gin.ResponseWriter
-	body *bytes.Buffer
+	body *cappedBuffer
 }
 
 func (w responseBodyLogger) Write(b []byte) (int, error) {
-	if w.body == nil {
-		w.body = &bytes.Buffer{}
-	}
 	w.body.Write(b)
 	return w.ResponseWriter.Write(b)
 }
 
-func (w responseBodyLogger) WriteHeader(statusCode int) {
-	w.ResponseWriter.WriteHeader(statusCode)
+func (w responseBodyLogger) WriteString(s string) (int, error) {
+	w.body.Write([]byte(s))
+	return w.ResponseWriter.WriteString(s)
 }
 
 func (w responseBodyLogger) Hijack() (net.Conn, *bufio.ReadWriter, error) {
@@ -37,43 +66,106 @@ func (w responseBodyLogger) Flush() {
 	w.ResponseWriter.(http.Flusher).Flush()
 }
 
+// LoggingMiddleware sets up the request logger (see bootstrap.Container.Log)
+// and writes one access log entry per request. Bodies are only logged when
+// log.access.body.enable is set, up to max_size bytes of the allowed content
+// types, with the log.access.redact paths masked.
 func LoggingMiddleware(cfg *bootstrap.Container) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		// Pin the log reference, in case CorrelationMiddleware did not, so every
+		// line of the request shares it.
+		logReff := identifier.GetLogReff(c)
+		identifier.SetLogReff(c, logReff)
 
-		ignoredPaths := cfg.GetConfig().GetStringSlice("log.ignore")
-		skipLog := false
+		fields := logrus.Fields{"logReff": logReff, "route": c.FullPath()}
+		if span := trace.SpanContextFromContext(c.Request.Context()); span.IsValid() {
+			fields["traceId"] = span.TraceID().String()
+		}
+		logger := cfg.Logger().WithFields(fields)
+		c.Request = c.Request.WithContext(bootstrap.ContextWithLogger(c.Request.Context(), logger))
 
-		if len(ignoredPaths) > 0 {
-			for _, path := range ignoredPaths {
-				if strings.HasPrefix(c.Request.URL.Path, path) {
-					skipLog = true
-					break
-				}
+		for _, path := range cfg.Config().Log.Ignore {
+			if strings.HasPrefix(c.Request.URL.Path, path) {
+				c.Next()
+				return
 			}
 		}
 
-		if !skipLog {
-			// Read the request body
-			var requestBody []byte
-			contentType := c.Request.Header.Get("Content-Type")
-			if strings.Contains(contentType, "application/json") && c.Request.Body != nil {
-				requestBody, _ = io.ReadAll(c.Request.Body)
-				c.Request.Body = io.NopCloser(bytes.NewBuffer(requestBody))
+		config := cfg.Config().Log.Access
+		requestBody := &cappedBuffer{}
+		responseBody := &cappedBuffer{}
+		if config.Body.Enable {
+			requestBody.limit = config.Body.MaxSize
+			responseBody.limit = config.Body.MaxSize
+		}
+
+		if c.Request.Body != nil {
+			c.Request.Body = requestBodyLogger{Reader: io.TeeReader(c.Request.Body, requestBody), Closer: c.Request.Body}
+		}
+		c.Writer = responseBodyLogger{ResponseWriter: c.Writer, body: responseBody}
+
+		start := time.Now()
+		c.Next()
+		latency := time.Since(start)
+
+		entry := cfg.Log(c).WithFields(logrus.Fields{
+			"method":        c.Request.Method,
+			"path":          c.Request.URL.Path,
+			"status":        c.Writer.Status(),
+			"latencyMs":     float64(latency.Microseconds()) / 1000,
+			"requestBytes":  max(int64(requestBody.size), c.Request.ContentLength),
+			"responseBytes": c.Writer.Size(),
+			"clientIp":      c.ClientIP(),
+			"userAgent":     c.Request.UserAgent(),
+		})
+
+		if config.Body.Enable {
+			if body, ok := loggableBody(config, c.ContentType(), requestBody); ok {
+				entry = entry.WithField("requestBody", body)
 			}
+			if body, ok := loggableBody(config, c.Writer.Header().Get("Content-Type"), responseBody); ok {
+				entry = entry.WithField("responseBody", body)
+			}
+		}
 
-			rw := &responseBodyLogger{body: bytes.NewBuffer([]byte{}), ResponseWriter: c.Writer}
-			c.Writer = rw
-			// Process the request
-			c.Next()
+		entry.Info("access")
+	}
+}
 
-			responseBody := rw.body.String()
+// loggableBody returns the captured body when its content type is allowed.
+// JSON bodies are only logged once redacted, so truncated or malformed ones
+// are replaced by their size.
+func loggableBody(config bootstrap.AccessLogConfig, contentType string, body *cappedBuffer) (string, bool) {
+	if body.size == 0 {
+		return "", false
+	}
 
-			// Log the request and response
-			cfg.Logger().Printf("METHOD : %s", c.Request.Method)
-			cfg.Logger().Printf("ENDPOINT : %s", c.Request.URL.Path)
-			cfg.Logger().Printf("REQUEST BODY : %s", string(requestBody))
-			cfg.Logger().Printf("RESPONSE STATUS : %d", c.Writer.Status())
-			cfg.Logger().Printf("RESPONSE BODY : %s", responseBody)
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	allowed := false
+	for _, allowedType := range config.Body.ContentTypes {
+		if strings.EqualFold(mediaType, allowedType) {
+			allowed = true
+			break
 		}
 	}
+	if !allowed {
+		return "", false
+	}
+
+	if mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") {
+		if body.truncated() {
+			return fmt.Sprintf("[truncated, %d bytes]", body.size), true
+		}
+		redacted, ok := helper.RedactJSON(body.body.Bytes(), config.Redact)
+		if !ok {
+			return fmt.Sprintf("[malformed, %d bytes]", body.size), true
+		}
+		return string(redacted), true
+	}
+
+	if body.truncated() {
+		return fmt.Sprintf("%s...[%d bytes]", body.body.String(), body.size), true
+	}
+
+	return body.body.String(), true
 }
@@ -0,0 +1,12 @@
+package middleware
+
+import (
+	"gogin-template/bootstrap"
+	"os"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	bootstrap.SetConfigPath("testdata")
+	os.Exit(m.Run())
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"gogin-template/bootstrap"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// MetricsMiddleware records request rate, errors and duration per route template.
+func MetricsMiddleware(cfg *bootstrap.Container) gin.HandlerFunc {
+	meter := cfg.GetMeter()
+
+	requests, err := meter.Int64Counter("http.server.requests",
+		metric.WithDescription("Number of HTTP requests handled"))
+	if err != nil {
+		cfg.Logger().Error(err)
+	}
+
+	failures, err := meter.Int64Counter("http.server.errors",
+		metric.WithDescription("Number of HTTP requests answered with a 5xx status"))
+	if err != nil {
+		cfg.Logger().Error(err)
+	}
+
+	duration, err := meter.Float64Histogram("http.server.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of HTTP requests"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10))
+	if err != nil {
+		cfg.Logger().Error(err)
+	}
+
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+
+		attrs := metric.WithAttributes(
+			attribute.String("http.method", c.Request.Method),
+			attribute.String("http.route", route),
+			attribute.String("http.status_code", strconv.Itoa(c.Writer.Status())),
+		)
+
+		ctx := c.Request.Context()
+		requests.Add(ctx, 1, attrs)
+		duration.Record(ctx, time.Since(start).Seconds(), attrs)
+		if c.Writer.Status() >= http.StatusInternalServerError {
+			failures.Add(ctx, 1, attrs)
+		}
+	}
+}
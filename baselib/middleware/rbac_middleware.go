@@ -0,0 +1,72 @@
+package middleware
+
+import (
+	"gogin-template/baselib/exception"
+	"gogin-template/baselib/identifier"
+	"gogin-template/bootstrap"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Policy maps roles to the permissions they grant, as configured in auth.rbac.roles.
+// A permission is "<resource>:<action>"; "*" and "<resource>:*" act as wildcards.
+type Policy struct {
+	roles map[string][]string
+}
+
+func NewPolicy(cfg *bootstrap.Container) *Policy {
+	roles := map[string][]string{}
+	for role, permissions := range cfg.Config().Auth.Rbac.Roles {
+		roles[strings.ToLower(role)] = permissions
+	}
+
+	return &Policy{roles: roles}
+}
+
+// Permissions returns what the caller is granted through its roles and scope claim.
+func (p *Policy) Permissions(claims *identifier.Claims) []string {
+	permissions := strings.Fields(claims.Scope)
+	for _, role := range claims.Roles {
+		permissions = append(permissions, p.roles[strings.ToLower(role)]...)
+	}
+
+	return permissions
+}
+
+func (p *Policy) Allowed(claims *identifier.Claims, permission string) bool {
+	resource, _, _ := strings.Cut(permission, ":")
+	for _, granted := range p.Permissions(claims) {
+		if granted == "*" || granted == permission || granted == resource+":*" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RbacMiddleware only lets the request through when the caller authenticated by
+// AuthMiddleware holds every one of the given permissions.
+func RbacMiddleware(cfg *bootstrap.Container, permissions ...string) gin.HandlerFunc {
+	policy := NewPolicy(cfg)
+
+	return func(c *gin.Context) {
+		claims := identifier.GetClaims(c.Request.Context())
+		if claims == nil {
+			c.Error(exception.New(exception.ERROR_UNAUTHORIZED, nil))
+			c.Abort()
+			return
+		}
+
+		for _, permission := range permissions {
+			if !policy.Allowed(claims, permission) {
+				cfg.Log(c).Infof("denied %s to %s: missing permission %s", c.FullPath(), claims.Subject, permission)
+				c.Error(exception.New(exception.ERROR_FORBIDDEN, nil))
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
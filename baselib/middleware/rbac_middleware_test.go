@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"errors"
+	"gogin-template/baselib/exception"
+	"gogin-template/baselib/identifier"
+	"gogin-template/bootstrap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestPolicyAllowed(t *testing.T) {
+	policy := &Policy{roles: map[string][]string{
+		"admin":    {"*"},
+		"maker":    {"sample:read", "sample:write"},
+		"approver": {"sample:*"},
+	}}
+
+	tests := []struct {
+		name       string
+		claims     identifier.Claims
+		permission string
+		allowed    bool
+	}{
+		{name: "role grants permission", claims: identifier.Claims{Roles: []string{"maker"}}, permission: "sample:write", allowed: true},
+		{name: "role is matched case insensitively", claims: identifier.Claims{Roles: []string{"Maker"}}, permission: "sample:read", allowed: true},
+		{name: "role lacks permission", claims: identifier.Claims{Roles: []string{"maker"}}, permission: "sample:approve", allowed: false},
+		{name: "resource wildcard", claims: identifier.Claims{Roles: []string{"approver"}}, permission: "sample:approve", allowed: true},
+		{name: "resource wildcard stays on its resource", claims: identifier.Claims{Roles: []string{"approver"}}, permission: "log:write", allowed: false},
+		{name: "global wildcard", claims: identifier.Claims{Roles: []string{"admin"}}, permission: "log:write", allowed: true},
+		{name: "unknown role", claims: identifier.Claims{Roles: []string{"guest"}}, permission: "sample:read", allowed: false},
+		{name: "scope claim", claims: identifier.Claims{Scope: "openid sample:read"}, permission: "sample:read", allowed: true},
+		{name: "no roles", claims: identifier.Claims{}, permission: "sample:read", allowed: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if allowed := policy.Allowed(&test.claims, test.permission); allowed != test.allowed {
+				t.Errorf("allowed = %v, want %v", allowed, test.allowed)
+			}
+		})
+	}
+}
+
+func TestRbacMiddleware(t *testing.T) {
+	// testdata/config.yaml grants maker sample:read and sample:write
+	cfg := bootstrap.Init()
+
+	tests := []struct {
+		name        string
+		claims      *identifier.Claims
+		permissions []string
+		status      int
+	}{
+		{name: "granted", claims: &identifier.Claims{Roles: []string{"maker"}}, permissions: []string{"sample:read"}, status: http.StatusOK},
+		{name: "every permission is required", claims: &identifier.Claims{Roles: []string{"maker"}}, permissions: []string{"sample:read", "sample:approve"}, status: http.StatusForbidden},
+		{name: "role denied", claims: &identifier.Claims{Roles: []string{"viewer"}}, permissions: []string{"sample:write"}, status: http.StatusForbidden},
+		{name: "not authenticated", claims: nil, permissions: []string{"sample:read"}, status: http.StatusUnauthorized},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				if test.claims != nil {
+					identifier.SetClaims(c, test.claims)
+				}
+				c.Next()
+
+				var e *exception.ErrorException
+				if last := c.Errors.Last(); last != nil && errors.As(last.Err, &e) {
+					c.Status(e.HttpStatusCode)
+				}
+			})
+			engine.GET("/", RbacMiddleware(cfg, test.permissions...), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			res := httptest.NewRecorder()
+			engine.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/", nil))
+			if res.Code != test.status {
+				t.Errorf("status = %d, want %d", res.Code, test.status)
+			}
+		})
+	}
+}
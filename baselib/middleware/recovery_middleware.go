@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"gogin-template/baselib/exception"
+	"gogin-template/baselib/identifier"
+	"gogin-template/bootstrap"
+	"net"
+	"os"
+	"runtime/debug"
+	"strings"
+	"syscall"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RecoveryMiddleware turns panics into an internal error, logged with its stack
+// trace, recorded on the request span and rendered like ExceptionMiddleware
+// would. It is registered right after otelgin, so the span is still open and
+// the panics of every other middleware are answered as well.
+func RecoveryMiddleware(cfg *bootstrap.Container) gin.HandlerFunc {
+	render := NewErrorRenderer(cfg)
+
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			err, ok := recovered.(error)
+			if !ok {
+				err = fmt.Errorf("%v", recovered)
+			}
+			stack := string(debug.Stack())
+
+			span := trace.SpanFromContext(c.Request.Context())
+			span.RecordError(err, trace.WithAttributes(attribute.String("exception.stacktrace", stack)))
+			span.SetStatus(codes.Error, "panic: "+err.Error())
+
+			// A client that went away cannot receive a response.
+			if brokenPipe(err) {
+				cfg.Log(c).Warn("connection closed by client: ", err)
+				c.Abort()
+				return
+			}
+
+			e := exception.New(exception.ERROR_INTERNAL, nil).WithCause(err)
+			cfg.Log(c).WithFields(logrus.Fields{
+				"panic": err.Error(),
+				"stack": stack,
+			}).Errorf("%s - %s - %s", e.ErrorCode, e.ErrorMessage, err)
+
+			// Part of the response is already out, it cannot be replaced.
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+
+			localized := *e
+			localized.ErrorMessage = e.Localize(identifier.GetLocale(c))
+			render(c, &localized)
+		}()
+
+		c.Next()
+	}
+}
+
+func brokenPipe(err error) bool {
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) {
+		return false
+	}
+
+	var syscallErr *os.SyscallError
+	if errors.As(opErr, &syscallErr) {
+		message := strings.ToLower(syscallErr.Error())
+		return strings.Contains(message, "broken pipe") || strings.Contains(message, "connection reset by peer")
+	}
+
+	return errors.Is(opErr, syscall.EPIPE) || errors.Is(opErr, syscall.ECONNRESET)
+}
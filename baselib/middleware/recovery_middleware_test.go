@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"encoding/json"
+	"gogin-template/baselib/dto"
+	"gogin-template/baselib/exception"
+	"gogin-template/bootstrap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestRecoveryMiddleware(t *testing.T) {
+	cfg := bootstrap.Init()
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name     string
+		handlers []gin.HandlerFunc
+		status   int
+		code     string
+	}{
+		{
+			name:     "handler panics",
+			handlers: []gin.HandlerFunc{func(c *gin.Context) { panic("boom") }},
+			status:   http.StatusInternalServerError,
+			code:     exception.ERROR_INTERNAL,
+		},
+		{
+			name: "middleware panics",
+			handlers: []gin.HandlerFunc{
+				func(c *gin.Context) { panic("boom") },
+				func(c *gin.Context) { c.Status(http.StatusOK) },
+			},
+			status: http.StatusInternalServerError,
+			code:   exception.ERROR_INTERNAL,
+		},
+		{
+			name: "response already written",
+			handlers: []gin.HandlerFunc{func(c *gin.Context) {
+				c.String(http.StatusAccepted, "partial")
+				panic("boom")
+			}},
+			status: http.StatusAccepted,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.Use(RecoveryMiddleware(cfg))
+			engine.GET("/", test.handlers...)
+
+			res := httptest.NewRecorder()
+			engine.ServeHTTP(res, httptest.NewRequest(http.MethodGet, "/", nil))
+			if res.Code != test.status {
+				t.Errorf("status = %d, want %d", res.Code, test.status)
+			}
+			if test.code == "" {
+				return
+			}
+
+			var body dto.Response[any]
+			if err := json.Unmarshal(res.Body.Bytes(), &body); err != nil {
+				t.Fatalf("body %q is not an envelope: %v", res.Body.String(), err)
+			}
+			if body.ResponseCode != test.code {
+				t.Errorf("response code = %q, want %q", body.ResponseCode, test.code)
+			}
+		})
+	}
+}
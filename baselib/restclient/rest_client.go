@@ -0,0 +1,181 @@
+package restclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"gogin-template/baselib/dto"
+	"gogin-template/baselib/exception"
+	"gogin-template/bootstrap"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// AuthFunc returns the Authorization header value of an outbound call.
+type AuthFunc func(ctx context.Context) (string, error)
+
+type RestClientOption func(client *RestClient)
+
+type RestClient struct {
+	baseUrl    string
+	headers    http.Header
+	auth       AuthFunc
+	clientOpts []bootstrap.HttpClientOption
+	client     *bootstrap.HttpClient
+	cfg        *bootstrap.Container
+}
+
+// WithHeader adds a header sent with every call of the client.
+func WithHeader(key string, value string) RestClientOption {
+	return func(client *RestClient) {
+		client.headers.Add(key, value)
+	}
+}
+
+// WithAuth resolves the Authorization header on every call.
+func WithAuth(auth AuthFunc) RestClientOption {
+	return func(client *RestClient) {
+		client.auth = auth
+	}
+}
+
+// WithBearerToken sends a static bearer token with every call.
+func WithBearerToken(token string) RestClientOption {
+	return WithAuth(func(ctx context.Context) (string, error) {
+		return "Bearer " + token, nil
+	})
+}
+
+// WithHttpClientOptions configures the underlying bootstrap.HttpClient, e.g. retries.
+func WithHttpClientOptions(opts ...bootstrap.HttpClientOption) RestClientOption {
+	return func(client *RestClient) {
+		client.clientOpts = append(client.clientOpts, opts...)
+	}
+}
+
+func NewRestClient(cfg *bootstrap.Container, baseUrl string, opts ...RestClientOption) *RestClient {
+	client := &RestClient{
+		baseUrl: strings.TrimSuffix(baseUrl, "/"),
+		headers: http.Header{},
+		cfg:     cfg,
+	}
+	client.headers.Set("Accept", "application/json")
+
+	for _, o := range opts {
+		o(client)
+	}
+
+	client.client = bootstrap.NewHttpClient(cfg, cfg.GetTracer(), client.clientOpts...)
+
+	return client
+}
+
+// Do sends body as JSON to path and decodes the standard response envelope.
+// Non-2xx statuses and envelopes whose responseCode is not a 2xx code are
+// returned as *exception.ErrorException keeping the downstream code and
+// message, only 5xx statuses and unreadable responses become 502.
+func Do[Req any, Res any](ctx context.Context, c *RestClient, method string, path string, body Req, opts ...bootstrap.WithHttpContext) (*dto.Response[Res], error) {
+	var reader io.Reader
+	if any(body) != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(payload)
+	}
+
+	authorization := ""
+	if c.auth != nil {
+		var err error
+		authorization, err = c.auth(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	setHeaders := func(r *http.Request) {
+		for key, values := range c.headers {
+			r.Header[key] = append([]string(nil), values...)
+		}
+		if reader != nil {
+			r.Header.Set("Content-Type", "application/json")
+		}
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+	}
+
+	res, err := c.client.Request(ctx, method, c.baseUrl+path, reader, append([]bootstrap.WithHttpContext{setHeaders}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	var envelope dto.Response[Res]
+	decodeErr := json.NewDecoder(res.Body).Decode(&envelope)
+	if decodeErr != nil && !errors.Is(decodeErr, io.EOF) {
+		if res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices {
+			return nil, exception.New(exception.ERROR_INVALID_RESPONSE, exception.Params{"method": method, "path": path}).WithCause(decodeErr)
+		}
+		envelope = dto.Response[Res]{}
+	}
+
+	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusMultipleChoices {
+		return &envelope, responseException(res, envelope.ResponseCode, envelope.ResponseMessage)
+	}
+
+	if envelope.ResponseCode != "" && !strings.HasPrefix(envelope.ResponseCode, "2") {
+		return &envelope, responseException(res, envelope.ResponseCode, envelope.ResponseMessage)
+	}
+
+	return &envelope, nil
+}
+
+func Get[Res any](ctx context.Context, c *RestClient, path string, opts ...bootstrap.WithHttpContext) (*dto.Response[Res], error) {
+	return Do[any, Res](ctx, c, bootstrap.HTTP_METHOD_GET, path, nil, opts...)
+}
+
+func Post[Req any, Res any](ctx context.Context, c *RestClient, path string, body Req, opts ...bootstrap.WithHttpContext) (*dto.Response[Res], error) {
+	return Do[Req, Res](ctx, c, bootstrap.HTTP_METHOD_POST, path, body, opts...)
+}
+
+func Put[Req any, Res any](ctx context.Context, c *RestClient, path string, body Req, opts ...bootstrap.WithHttpContext) (*dto.Response[Res], error) {
+	return Do[Req, Res](ctx, c, bootstrap.HTTP_METHOD_PUT, path, body, opts...)
+}
+
+func Delete[Res any](ctx context.Context, c *RestClient, path string, opts ...bootstrap.WithHttpContext) (*dto.Response[Res], error) {
+	return Do[any, Res](ctx, c, bootstrap.HTTP_METHOD_DELETE, path, nil, opts...)
+}
+
+// responseException keeps the status of a 4xx response. A failure reported by
+// the envelope of a 2xx response takes its code as status when it is a 4xx
+// one and 422 otherwise, the downstream answered but refused the call.
+func responseException(res *http.Response, code string, message string) *exception.ErrorException {
+	status := res.StatusCode
+	switch {
+	case status >= http.StatusOK && status < http.StatusMultipleChoices:
+		status = http.StatusUnprocessableEntity
+		if parsed, err := strconv.Atoi(code); err == nil && parsed >= http.StatusBadRequest && parsed < http.StatusInternalServerError {
+			status = parsed
+		}
+	case status < http.StatusBadRequest || status >= http.StatusInternalServerError:
+		status = http.StatusBadGateway
+	}
+
+	if code == "" {
+		code = strconv.Itoa(res.StatusCode)
+	}
+	if message == "" {
+		message = fmt.Sprintf("%s %s responded %s", res.Request.Method, res.Request.URL.Path, res.Status)
+	}
+
+	return &exception.ErrorException{
+		ErrorCode:      code,
+		ErrorMessage:   message,
+		HttpStatusCode: status,
+	}
+}
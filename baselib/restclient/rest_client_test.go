@@ -0,0 +1,59 @@
+package restclient
+
+import (
+	"context"
+	"errors"
+	"gogin-template/baselib/exception"
+	"gogin-template/bootstrap"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDoErrors(t *testing.T) {
+	cfg := bootstrap.Init()
+
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		code    string
+		message string
+		http    int
+	}{
+		{name: "success", status: http.StatusOK, body: `{"responseCode":"200","data":"ok"}`},
+		{name: "client error", status: http.StatusNotFound, body: `{"responseCode":"SMP-0001","responseMessage":"Sample S1 not found"}`, code: "SMP-0001", message: "Sample S1 not found", http: http.StatusNotFound},
+		{name: "server error", status: http.StatusInternalServerError, body: `{"responseCode":"GEN-0500","responseMessage":"boom"}`, code: "GEN-0500", message: "boom", http: http.StatusBadGateway},
+		{name: "server error without envelope", status: http.StatusServiceUnavailable, body: `<html>`, code: "503", message: "GET /sample responded 503 Service Unavailable", http: http.StatusBadGateway},
+		{name: "business code in a 2xx envelope", status: http.StatusOK, body: `{"responseCode":"SMP-0001","responseMessage":"Sample S1 not found"}`, code: "SMP-0001", message: "Sample S1 not found", http: http.StatusUnprocessableEntity},
+		{name: "4xx code in a 2xx envelope", status: http.StatusOK, body: `{"responseCode":"409","responseMessage":"locked"}`, code: "409", message: "locked", http: http.StatusConflict},
+		{name: "5xx code in a 2xx envelope", status: http.StatusOK, body: `{"responseCode":"500","responseMessage":"failed"}`, code: "500", message: "failed", http: http.StatusUnprocessableEntity},
+		{name: "unreadable 2xx response", status: http.StatusOK, body: `<html>`, code: exception.ERROR_INVALID_RESPONSE, message: "GET /sample returned a response that could not be read", http: http.StatusBadGateway},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+				w.Write([]byte(test.body))
+			}))
+			defer server.Close()
+
+			_, err := Get[string](context.Background(), NewRestClient(cfg, server.URL), "/sample")
+			if test.code == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var e *exception.ErrorException
+			if !errors.As(err, &e) {
+				t.Fatalf("error = %v, want an *exception.ErrorException", err)
+			}
+			if e.ErrorCode != test.code || e.ErrorMessage != test.message || e.HttpStatusCode != test.http {
+				t.Errorf("exception = %s %q %d, want %s %q %d", e.ErrorCode, e.ErrorMessage, e.HttpStatusCode, test.code, test.message, test.http)
+			}
+		})
+	}
+}
@@ -1,64 +1,41 @@
 package restclient
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"fmt"
 	"gogin-template/baselib/dto"
 	"gogin-template/bootstrap"
 	"net/http"
 )
 
 type SampleClient struct {
-	url      string
 	endpoint string
-	client   *bootstrap.HttpClient
-	cfg      *bootstrap.Container
+	client   *RestClient
 }
 
-func NewSampleClient(url string, endpoint string, cfg *bootstrap.Container) *SampleClient {
-	client := bootstrap.NewHttpClient(cfg, cfg.GetTracer())
+type SampleClientRq struct {
+	ClientId      string
+	CookiesDomain string
+	CookiesPrefix string
+}
+
+func NewSampleClient(url string, endpoint string, cfg *bootstrap.Container, opts ...bootstrap.HttpClientOption) *SampleClient {
+	clientOpts := append([]bootstrap.HttpClientOption{bootstrap.WithClientConfig("sample")}, opts...)
 	return &SampleClient{
-		url:      url,
 		endpoint: endpoint,
-		client:   client,
-		cfg:      cfg,
+		client:   NewRestClient(cfg, url, WithHttpClientOptions(clientOpts...)),
 	}
 }
 
 func (c *SampleClient) Post(ctx context.Context, auth string, clientId string,
 	cookiesDomain string, cookiesPrefix string) (*dto.Response[bool], error) {
 
-	data := map[string]interface{}{
-		"ClientId":      clientId,
-		"CookiesDomain": cookiesDomain,
-		"CookiesPrefix": cookiesPrefix,
-	}
-
-	jsonBytes, err := json.Marshal(data)
-	if err != nil {
-		return nil, err
-	}
-
-	addHeaderFunc := func(r *http.Request) {
-		//* Do something with the request object
-		r.Header.Add("Authorization", auth)
-		r.Header.Add("Content-Type", "application/json")
-	}
-
-	resp, err := c.client.Post(ctx, fmt.Sprintf("%s%s", c.url, c.endpoint), bytes.NewReader(jsonBytes), addHeaderFunc)
-
-	if err != nil {
-		return nil, err
-	}
-
-	var res dto.Response[bool]
-	err = json.NewDecoder(resp.Body).Decode(&res)
-
-	if err != nil {
-		return nil, err
+	data := SampleClientRq{
+		ClientId:      clientId,
+		CookiesDomain: cookiesDomain,
+		CookiesPrefix: cookiesPrefix,
 	}
 
-	return &res, err
+	return Post[SampleClientRq, bool](ctx, c.client, c.endpoint, data, func(r *http.Request) {
+		r.Header.Set("Authorization", auth)
+	})
 }
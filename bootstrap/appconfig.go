@@ -0,0 +1,359 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mitchellh/mapstructure"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+)
+
+// AppConfig is the typed form of the config file, unmarshalled and validated
+// once by Init. Keys that are not part of it stay reachable through GetConfig.
+// Fields tagged reload are applied by WatchConfig while the service runs.
+type AppConfig struct {
+	Server              ServerConfig              `mapstructure:"server"`
+	Health              HealthConfig              `mapstructure:"health"`
+	Database            DatabaseConfig            `mapstructure:"database"`
+	I18n                I18nConfig                `mapstructure:"i18n"`
+	Auth                AuthConfig                `mapstructure:"auth"`
+	ClientConfiguration ClientConfigurationConfig `mapstructure:"client_configuration"`
+	Telemetry           TelemetryConfig           `mapstructure:"telemetry"`
+	Log                 LogConfig                 `mapstructure:"log"`
+	Features            map[string]bool           `mapstructure:"features" reload:"true"`
+}
+
+type ServerConfig struct {
+	Port          int               `mapstructure:"port" validate:"min=1,max=65535"`
+	ShutdownDelay time.Duration     `mapstructure:"shutdown_delay" validate:"min=0"`
+	Error         ServerErrorConfig `mapstructure:"error"`
+	Correlation   CorrelationConfig `mapstructure:"correlation"`
+}
+
+type CorrelationConfig struct {
+	Headers []string `mapstructure:"headers" validate:"min=1,dive,required"` // The first one is also sent on outbound calls
+}
+
+type ServerErrorConfig struct {
+	Format   string `mapstructure:"format" validate:"oneof=envelope problem"`
+	TypeBase string `mapstructure:"type_base"`
+}
+
+type HealthConfig struct {
+	Timeout  time.Duration `mapstructure:"timeout" validate:"gt=0"`
+	CacheTTL time.Duration `mapstructure:"cache_ttl" validate:"min=0"`
+}
+
+type DatabaseConfig struct {
+	Driver    string          `mapstructure:"driver" validate:"required"`
+	Postgres  PostgresConfig  `mapstructure:"postgres"`
+	Migration MigrationConfig `mapstructure:"migration"`
+}
+
+type PostgresConfig struct {
+	Write PostgresConnectionConfig `mapstructure:"write"`
+	Read  PostgresConnectionConfig `mapstructure:"read"`
+}
+
+// PostgresConnectionConfig holds a DSN where {username} and {password} are
+// replaced by the fields of the same name.
+type PostgresConnectionConfig struct {
+	Connection string `mapstructure:"connection" validate:"required"`
+	Username   string `mapstructure:"username"`
+	Password   string `mapstructure:"password"`
+}
+
+// Dsn returns the connection string with the credentials filled in.
+func (p PostgresConnectionConfig) Dsn() string {
+	dsn := strings.Replace(p.Connection, "{username}", p.Username, 1)
+	return strings.Replace(dsn, "{password}", p.Password, 1)
+}
+
+type MigrationConfig struct {
+	Dir   string `mapstructure:"dir" validate:"required"`
+	Table string `mapstructure:"table" validate:"required"`
+}
+
+type I18nConfig struct {
+	Dir string `mapstructure:"dir"`
+}
+
+type AuthConfig struct {
+	Jwt  JwtConfig  `mapstructure:"jwt"`
+	Rbac RbacConfig `mapstructure:"rbac"`
+}
+
+type JwtConfig struct {
+	Issuer   string         `mapstructure:"issuer"`
+	Audience string         `mapstructure:"audience"`
+	Leeway   int            `mapstructure:"leeway" validate:"min=0"` // Seconds
+	Keys     []JwtKeyConfig `mapstructure:"keys" validate:"dive"`
+	Jwks     JwksConfig     `mapstructure:"jwks"`
+}
+
+type JwtKeyConfig struct {
+	Kid           string `mapstructure:"kid" validate:"required"`
+	Alg           string `mapstructure:"alg" validate:"oneof=HS256 RS256 ES256"`
+	Secret        string `mapstructure:"secret" validate:"required_if=Alg HS256,omitempty,min=32,not_placeholder"` // 256 bits at least for HS256
+	PublicKey     string `mapstructure:"public_key"`
+	PublicKeyFile string `mapstructure:"public_key_file"`
+}
+
+type JwksConfig struct {
+	Path    string `mapstructure:"path"`
+	Refresh int    `mapstructure:"refresh" validate:"min=0"` // Seconds
+}
+
+type RbacConfig struct {
+	Roles map[string][]string `mapstructure:"roles"`
+}
+
+type ClientConfigurationConfig struct {
+	Timeout   int                     `mapstructure:"timeout" validate:"min=0"` // Seconds, 0 disables it
+	Transport TransportConfig         `mapstructure:"transport"`
+	Tls       TlsConfig               `mapstructure:"tls"`
+	Clients   map[string]ClientConfig `mapstructure:"clients" validate:"dive"`
+}
+
+type TransportConfig struct {
+	MaxIdleConns        int           `mapstructure:"max_idle_conns" validate:"min=0"`
+	MaxIdleConnsPerHost int           `mapstructure:"max_idle_conns_per_host" validate:"min=0"`
+	MaxConnsPerHost     int           `mapstructure:"max_conns_per_host" validate:"min=0"`
+	IdleConnTimeout     time.Duration `mapstructure:"idle_conn_timeout" validate:"min=0"`
+	TlsHandshakeTimeout time.Duration `mapstructure:"tls_handshake_timeout" validate:"min=0"`
+}
+
+type TlsConfig struct {
+	CaFile             string `mapstructure:"ca_file"`
+	CertFile           string `mapstructure:"cert_file" validate:"required_with=KeyFile"`
+	KeyFile            string `mapstructure:"key_file" validate:"required_with=CertFile"`
+	MinVersion         string `mapstructure:"min_version" validate:"omitempty,oneof=1.2 1.3"`
+	ServerName         string `mapstructure:"server_name"`
+	InsecureSkipVerify bool   `mapstructure:"insecure_skip_verify"`
+}
+
+// ClientConfig configures the HttpClient created with WithClientConfig, nil
+// sections stay disabled.
+type ClientConfig struct {
+	HealthUrl      string                  `mapstructure:"health_url" validate:"omitempty,url"`
+	Tls            *TlsConfig              `mapstructure:"tls"`
+	Retry          *RetryPolicy            `mapstructure:"retry"`
+	CircuitBreaker *CircuitBreakerSettings `mapstructure:"circuit_breaker"`
+}
+
+type TelemetryConfig struct {
+	Enable      bool                    `mapstructure:"enable"`
+	Exporter    TelemetryExporterConfig `mapstructure:"exporter"`
+	Jaeger      JaegerConfig            `mapstructure:"jaeger"`
+	Propagators []string                `mapstructure:"propagators" validate:"dive,oneof=tracecontext baggage b3 b3multi"`
+	Resource    TelemetryResourceConfig `mapstructure:"resource"`
+	Metrics     MetricsConfig           `mapstructure:"metrics"`
+}
+
+type TelemetryExporterConfig struct {
+	Type        string            `mapstructure:"type" validate:"oneof=otlp-grpc otlp-http stdout none"`
+	Endpoint    string            `mapstructure:"endpoint"` // host:port, defaults to jaeger.agent_host:agent_port
+	Insecure    bool              `mapstructure:"insecure"` // Plain text instead of TLS
+	Tls         TlsConfig         `mapstructure:"tls"`
+	Headers     map[string]string `mapstructure:"headers"`
+	Compression string            `mapstructure:"compression" validate:"oneof=none gzip"`
+	Timeout     time.Duration     `mapstructure:"timeout" validate:"min=0"`
+}
+
+type JaegerConfig struct {
+	TraceRatio float64 `mapstructure:"trace_ratio" validate:"min=0,max=1"` // Share of new traces sampled, parents decide for the rest
+	AgentHost  string  `mapstructure:"agent_host"`
+	AgentPort  int     `mapstructure:"agent_port" validate:"min=0,max=65535"`
+}
+
+type TelemetryResourceConfig struct {
+	Version    string            `mapstructure:"version"` // service.version, defaults to SERVICE_VERSION
+	Attributes map[string]string `mapstructure:"attributes"`
+}
+
+type MetricsConfig struct {
+	Enable bool `mapstructure:"enable"`
+}
+
+type LogConfig struct {
+	Level   string            `mapstructure:"level" validate:"oneof=trace debug info warn warning error fatal panic" reload:"true"`
+	Format  string            `mapstructure:"format" validate:"oneof=json text logfmt"`
+	Outputs []LogOutputConfig `mapstructure:"outputs" validate:"dive"` // Stdout when empty
+	Ignore  []string          `mapstructure:"ignore" reload:"true"`
+	Access  AccessLogConfig   `mapstructure:"access" reload:"true"`
+}
+
+type AccessLogConfig struct {
+	Body   AccessLogBodyConfig `mapstructure:"body"`
+	Redact []string            `mapstructure:"redact"` // JSON paths masked in logged bodies
+}
+
+type AccessLogBodyConfig struct {
+	Enable       bool     `mapstructure:"enable"`
+	MaxSize      int      `mapstructure:"max_size" validate:"min=0"` // Bytes kept per body
+	ContentTypes []string `mapstructure:"content_types"`
+}
+
+type LogOutputConfig struct {
+	Type       string `mapstructure:"type" validate:"oneof=stdout stderr file"`
+	Path       string `mapstructure:"path" validate:"required_if=Type file"`
+	MaxSize    int    `mapstructure:"max_size" validate:"min=0"`    // Megabytes before the file is rotated
+	MaxAge     int    `mapstructure:"max_age" validate:"min=0"`     // Days rotated files are kept, 0 keeps them
+	MaxBackups int    `mapstructure:"max_backups" validate:"min=0"` // Rotated files kept, 0 keeps them all
+	Compress   bool   `mapstructure:"compress"`
+}
+
+// ConfigError lists every invalid key found while loading the config.
+type ConfigError struct {
+	Problems []string
+}
+
+func (e *ConfigError) Error() string {
+	return "invalid config:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+func setConfigDefaults(vip *viper.Viper) {
+	vip.SetDefault("server.port", 8080)
+	vip.SetDefault("server.shutdown_delay", 0)
+	vip.SetDefault("server.error.format", "envelope")
+	vip.SetDefault("server.correlation.headers", []string{"X-Request-ID", "X-Log-Reff"})
+	vip.SetDefault("health.timeout", HEALTH_DEFAULT_TIMEOUT)
+	vip.SetDefault("health.cache_ttl", HEALTH_DEFAULT_CACHE_TTL)
+	vip.SetDefault("database.driver", "pgx")
+	vip.SetDefault("database.migration.dir", MIGRATION_DEFAULT_DIR)
+	vip.SetDefault("database.migration.table", MIGRATION_DEFAULT_TABLE)
+	vip.SetDefault("i18n.dir", "i18n")
+	vip.SetDefault("auth.jwt.leeway", 0)
+	vip.SetDefault("auth.jwt.jwks.refresh", 300)
+	vip.SetDefault("client_configuration.timeout", 30)
+	vip.SetDefault("client_configuration.transport.max_idle_conns", 100)
+	vip.SetDefault("client_configuration.transport.idle_conn_timeout", 90*time.Second)
+	vip.SetDefault("client_configuration.transport.tls_handshake_timeout", 10*time.Second)
+	vip.SetDefault("telemetry.jaeger.trace_ratio", 1)
+	vip.SetDefault("telemetry.exporter.type", TELEMETRY_EXPORTER_OTLP_HTTP)
+	vip.SetDefault("telemetry.exporter.compression", "none")
+	vip.SetDefault("telemetry.exporter.timeout", 10*time.Second)
+	vip.SetDefault("telemetry.propagators", []string{TELEMETRY_PROPAGATOR_TRACECONTEXT, TELEMETRY_PROPAGATOR_BAGGAGE, TELEMETRY_PROPAGATOR_B3})
+	vip.SetDefault("log.format", LOG_FORMAT_JSON)
+	vip.SetDefault("log.access.body.max_size", 4096)
+	vip.SetDefault("log.access.body.content_types", []string{"application/json"})
+	vip.SetDefault("log.access.redact", []string{"password", "token", "accessToken", "refreshToken", "secret", "cardNumber", "cvv"})
+
+	// Only local environments log at debug level by default.
+	switch strings.ToLower(os.Getenv("ENV")) {
+	case "", "local", "dev", "development":
+		vip.SetDefault("log.level", logrus.DebugLevel.String())
+	default:
+		vip.SetDefault("log.level", logrus.InfoLevel.String())
+	}
+
+	// Sections of a client start from the defaults of HttpClient.
+	for name := range vip.GetStringMap("client_configuration.clients") {
+		prefix := "client_configuration.clients." + name
+		if vip.IsSet(prefix + ".retry") {
+			policy := DefaultRetryPolicy()
+			vip.SetDefault(prefix+".retry.max_attempts", policy.MaxAttempts)
+			vip.SetDefault(prefix+".retry.initial_backoff", policy.InitialBackoff)
+			vip.SetDefault(prefix+".retry.max_backoff", policy.MaxBackoff)
+			vip.SetDefault(prefix+".retry.multiplier", policy.Multiplier)
+			vip.SetDefault(prefix+".retry.retry_on", policy.RetryOn)
+		}
+		if vip.IsSet(prefix + ".circuit_breaker") {
+			settings := DefaultCircuitBreakerSettings()
+			vip.SetDefault(prefix+".circuit_breaker.failure_threshold", settings.FailureThreshold)
+			vip.SetDefault(prefix+".circuit_breaker.open_timeout", settings.OpenTimeout)
+			vip.SetDefault(prefix+".circuit_breaker.half_open_requests", settings.HalfOpenRequests)
+		}
+	}
+}
+
+// bindConfigEnv binds every key of typ so environment variables also reach
+// keys that are absent from the config file.
+func bindConfigEnv(vip *viper.Viper, typ reflect.Type, prefix string) {
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		key, ok := field.Tag.Lookup("mapstructure")
+		if !ok {
+			continue
+		}
+		key = prefix + key
+
+		if field.Type.Kind() == reflect.Struct {
+			bindConfigEnv(vip, field.Type, key+".")
+			continue
+		}
+		if field.Type.Kind() != reflect.Map {
+			vip.BindEnv(key)
+		}
+	}
+}
+
+// UnmarshalConfig decodes vip into an AppConfig and validates it, every
+// problem is reported at once in a *ConfigError.
+func UnmarshalConfig(vip *viper.Viper) (*AppConfig, error) {
+	config := &AppConfig{}
+	if err := vip.Unmarshal(config); err != nil {
+		return nil, &ConfigError{Problems: decodeProblems(err)}
+	}
+
+	validate := validator.New()
+	validate.RegisterTagNameFunc(func(field reflect.StructField) string {
+		return field.Tag.Get("mapstructure")
+	})
+	validate.RegisterValidation("not_placeholder", func(fl validator.FieldLevel) bool {
+		return !configPlaceholderPattern.MatchString(fl.Field().String())
+	})
+
+	err := validate.Struct(config)
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		problems := make([]string, len(validationErrors))
+		for i, fieldError := range validationErrors {
+			_, key, _ := strings.Cut(fieldError.Namespace(), ".")
+			rule := fieldError.Tag()
+			if fieldError.Param() != "" {
+				rule += "=" + fieldError.Param()
+			}
+			// The value is left out, it may be a password or a resolved secret
+			problems[i] = fmt.Sprintf("%s: does not satisfy %s", key, rule)
+		}
+		return nil, &ConfigError{Problems: problems}
+	} else if err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// configPlaceholderPattern matches the values left from an example config,
+// such as change-me, that must never reach a running service.
+var configPlaceholderPattern = regexp.MustCompile(`(?i)change[-_ ]?me|replace[-_ ]?me|placeholder|^<.*>$`)
+
+var decodeKeyPattern = regexp.MustCompile(`'([^']*)'`)
+
+// decodeProblems reports the keys mapstructure failed to decode without the
+// offending values it puts in its messages.
+func decodeProblems(err error) []string {
+	var decodeError *mapstructure.Error
+	if !errors.As(err, &decodeError) {
+		return []string{"config could not be decoded"}
+	}
+
+	problems := make([]string, len(decodeError.Errors))
+	for i, message := range decodeError.Errors {
+		key := "config"
+		if match := decodeKeyPattern.FindStringSubmatch(message); match != nil && match[1] != "" {
+			key = match[1]
+		}
+		problems[i] = key + ": has an invalid type"
+	}
+
+	return problems
+}
@@ -0,0 +1,183 @@
+package bootstrap
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+const appConfigTestDatabase = `
+database:
+  postgres:
+    write:
+      connection: postgres://localhost/test
+    read:
+      connection: postgres://localhost/test
+`
+
+func appConfigTestLoad(t *testing.T, content string) (*AppConfig, error) {
+	t.Helper()
+
+	vip := viper.New()
+	vip.SetConfigType("yaml")
+	if err := vip.ReadConfig(strings.NewReader(content)); err != nil {
+		t.Fatalf("failed to read config: %v", err)
+	}
+	setConfigDefaults(vip)
+
+	return UnmarshalConfig(vip)
+}
+
+func TestUnmarshalConfigDefaults(t *testing.T) {
+	config, err := appConfigTestLoad(t, appConfigTestDatabase)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if config.Server.Port != 8080 || config.Server.Error.Format != "envelope" || config.Health.Timeout != HEALTH_DEFAULT_TIMEOUT {
+		t.Errorf("config = %+v, want the defaults filled in", config)
+	}
+	if config.Database.Migration.Table != MIGRATION_DEFAULT_TABLE {
+		t.Errorf("migration table = %q, want %q", config.Database.Migration.Table, MIGRATION_DEFAULT_TABLE)
+	}
+}
+
+func TestUnmarshalConfigClientDefaults(t *testing.T) {
+	config, err := appConfigTestLoad(t, appConfigTestDatabase+`
+client_configuration:
+  clients:
+    sample:
+      retry:
+        max_attempts: 5
+`)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	retry := config.ClientConfiguration.Clients["sample"].Retry
+	want := DefaultRetryPolicy()
+	want.MaxAttempts = 5
+	if retry == nil || !reflect.DeepEqual(*retry, want) {
+		t.Errorf("retry = %+v, want %+v", retry, want)
+	}
+	if config.ClientConfiguration.Clients["sample"].CircuitBreaker != nil {
+		t.Errorf("circuit breaker = %+v, want it left disabled", config.ClientConfiguration.Clients["sample"].CircuitBreaker)
+	}
+}
+
+func TestUnmarshalConfigInvalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		problems []string
+	}{
+		{
+			name:    "missing connections",
+			content: "server:\n  port: 8080\n",
+			problems: []string{
+				`database.postgres.write.connection: does not satisfy required`,
+				`database.postgres.read.connection: does not satisfy required`,
+			},
+		},
+		{
+			name:     "port out of range",
+			content:  appConfigTestDatabase + "server:\n  port: 70000\n",
+			problems: []string{`server.port: does not satisfy max=65535`},
+		},
+		{
+			name:     "unknown error format",
+			content:  appConfigTestDatabase + "server:\n  error:\n    format: xml\n",
+			problems: []string{`server.error.format: does not satisfy oneof=envelope problem`},
+		},
+		{
+			name:     "jwt algorithm",
+			content:  appConfigTestDatabase + "auth:\n  jwt:\n    keys:\n      - kid: k1\n        alg: HS512\n",
+			problems: []string{`auth.jwt.keys[0].alg: does not satisfy oneof=HS256 RS256 ES256`},
+		},
+		{
+			name:     "hmac key without secret",
+			content:  appConfigTestDatabase + "auth:\n  jwt:\n    keys:\n      - kid: k1\n        alg: HS256\n",
+			problems: []string{`auth.jwt.keys[0].secret: does not satisfy required_if=Alg HS256`},
+		},
+		{
+			name:     "hmac key with a placeholder secret",
+			content:  appConfigTestDatabase + "auth:\n  jwt:\n    keys:\n      - kid: k1\n        alg: HS256\n        secret: change-me-to-a-long-random-value-please\n",
+			problems: []string{`auth.jwt.keys[0].secret: does not satisfy not_placeholder`},
+		},
+		{
+			name:     "hmac key with a short secret",
+			content:  appConfigTestDatabase + "auth:\n  jwt:\n    keys:\n      - kid: k1\n        alg: HS256\n        secret: s3cr3t\n",
+			problems: []string{`auth.jwt.keys[0].secret: does not satisfy min=32`},
+		},
+		{
+			name:     "certificate without key",
+			content:  appConfigTestDatabase + "client_configuration:\n  tls:\n    cert_file: client.pem\n",
+			problems: []string{`client_configuration.tls.key_file: does not satisfy required_with=CertFile`},
+		},
+		{
+			name:     "client health url",
+			content:  appConfigTestDatabase + "client_configuration:\n  clients:\n    sample:\n      health_url: not a url\n",
+			problems: []string{`client_configuration.clients[sample].health_url: does not satisfy url`},
+		},
+		{
+			name:    "every problem at once",
+			content: "server:\n  port: 0\nhealth:\n  timeout: 0s\n",
+			problems: []string{
+				`server.port: does not satisfy min=1`,
+				`health.timeout: does not satisfy gt=0`,
+				`database.postgres.write.connection: does not satisfy required`,
+				`database.postgres.read.connection: does not satisfy required`,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := appConfigTestLoad(t, test.content)
+
+			var configErr *ConfigError
+			if !errors.As(err, &configErr) {
+				t.Fatalf("error = %v, want a *ConfigError", err)
+			}
+			if !reflect.DeepEqual(configErr.Problems, test.problems) {
+				t.Errorf("problems = %q, want %q", configErr.Problems, test.problems)
+			}
+		})
+	}
+}
+
+func TestUnmarshalConfigHidesValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		problems []string
+	}{
+		{
+			name:     "decode error",
+			content:  appConfigTestDatabase + "server:\n  port: s3cr3t-port\n",
+			problems: []string{"server.port: has an invalid type"},
+		},
+		{
+			name:     "validation error",
+			content:  appConfigTestDatabase + "server:\n  error:\n    format: s3cr3t-format\n",
+			problems: []string{"server.error.format: does not satisfy oneof=envelope problem"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := appConfigTestLoad(t, test.content)
+
+			var configErr *ConfigError
+			if !errors.As(err, &configErr) || !reflect.DeepEqual(configErr.Problems, test.problems) {
+				t.Errorf("error = %v, want problems %q", err, test.problems)
+			}
+			if err != nil && strings.Contains(err.Error(), "s3cr3t") {
+				t.Errorf("error = %v, want the value left out", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package bootstrap
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+const (
+	CIRCUIT_STATE_CLOSED    string = "closed"
+	CIRCUIT_STATE_OPEN      string = "open"
+	CIRCUIT_STATE_HALF_OPEN string = "half-open"
+)
+
+type CircuitBreakerSettings struct {
+	FailureThreshold int           `mapstructure:"failure_threshold" validate:"min=1"`  // Consecutive failures that open the circuit
+	OpenTimeout      time.Duration `mapstructure:"open_timeout" validate:"gt=0"`        // Time the circuit stays open before probing
+	HalfOpenRequests int           `mapstructure:"half_open_requests" validate:"min=1"` // Probes allowed at once while half-open
+}
+
+func DefaultCircuitBreakerSettings() CircuitBreakerSettings {
+	return CircuitBreakerSettings{
+		FailureThreshold: 5,
+		OpenTimeout:      30 * time.Second,
+		HalfOpenRequests: 1,
+	}
+}
+
+// CircuitBreaker fails calls fast while a downstream keeps failing. After
+// OpenTimeout it lets HalfOpenRequests probes through; a successful probe
+// closes the circuit again and a failed one reopens it.
+type CircuitBreaker struct {
+	settings  CircuitBreakerSettings
+	onChange  func(from string, to string)
+	mu        sync.Mutex
+	state     string
+	failures  int
+	probes    int
+	openUntil time.Time
+}
+
+func NewCircuitBreaker(settings CircuitBreakerSettings, onChange func(from string, to string)) *CircuitBreaker {
+	if settings.FailureThreshold <= 0 {
+		settings.FailureThreshold = DefaultCircuitBreakerSettings().FailureThreshold
+	}
+	if settings.HalfOpenRequests <= 0 {
+		settings.HalfOpenRequests = 1
+	}
+
+	return &CircuitBreaker{settings: settings, onChange: onChange, state: CIRCUIT_STATE_CLOSED}
+}
+
+func (b *CircuitBreaker) State() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// Allow reserves a call, it returns ErrCircuitOpen when the call must not be made.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	notify, err := b.allow()
+	b.mu.Unlock()
+
+	notify()
+	return err
+}
+
+func (b *CircuitBreaker) allow() (func(), error) {
+	notify := func() {}
+	if b.state == CIRCUIT_STATE_OPEN {
+		if time.Now().Before(b.openUntil) {
+			return notify, ErrCircuitOpen
+		}
+		notify = b.transition(CIRCUIT_STATE_HALF_OPEN)
+	}
+
+	if b.state == CIRCUIT_STATE_HALF_OPEN {
+		if b.probes >= b.settings.HalfOpenRequests {
+			return notify, ErrCircuitOpen
+		}
+		b.probes++
+	}
+
+	return notify, nil
+}
+
+// Report records the outcome of a call previously allowed by Allow.
+func (b *CircuitBreaker) Report(success bool) {
+	b.mu.Lock()
+	notify := b.report(success)
+	b.mu.Unlock()
+
+	notify()
+}
+
+func (b *CircuitBreaker) report(success bool) func() {
+	if b.state == CIRCUIT_STATE_HALF_OPEN && b.probes > 0 {
+		b.probes--
+	}
+
+	if success {
+		b.failures = 0
+		if b.state != CIRCUIT_STATE_CLOSED {
+			return b.transition(CIRCUIT_STATE_CLOSED)
+		}
+		return func() {}
+	}
+
+	b.failures++
+	if b.state == CIRCUIT_STATE_HALF_OPEN || b.failures >= b.settings.FailureThreshold {
+		b.openUntil = time.Now().Add(b.settings.OpenTimeout)
+		if b.state != CIRCUIT_STATE_OPEN {
+			return b.transition(CIRCUIT_STATE_OPEN)
+		}
+	}
+
+	return func() {}
+}
+
+// transition changes the state under b.mu and returns the onChange call to
+// make once it is released, so the callback may use the breaker itself.
+func (b *CircuitBreaker) transition(to string) func() {
+	from := b.state
+	b.state = to
+	b.probes = 0
+	if b.onChange == nil {
+		return func() {}
+	}
+
+	return func() { b.onChange(from, to) }
+}
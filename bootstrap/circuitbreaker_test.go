@@ -0,0 +1,130 @@
+package bootstrap
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	const (
+		allow   = "allow"
+		succeed = "succeed"
+		fail    = "fail"
+		expire  = "expire" // Ends the open timeout
+	)
+
+	tests := []struct {
+		name        string
+		steps       []string
+		state       string
+		allowed     bool
+		transitions []string
+	}{
+		{
+			name:    "stays closed below the threshold",
+			steps:   []string{allow, fail, allow, succeed, allow, fail, allow, fail},
+			state:   CIRCUIT_STATE_CLOSED,
+			allowed: true,
+		},
+		{
+			name:        "opens at the threshold",
+			steps:       []string{allow, fail, allow, fail, allow, fail},
+			state:       CIRCUIT_STATE_OPEN,
+			allowed:     false,
+			transitions: []string{"closed>open"},
+		},
+		{
+			name:        "half-opens after the timeout",
+			steps:       []string{allow, fail, allow, fail, allow, fail, expire, allow},
+			state:       CIRCUIT_STATE_HALF_OPEN,
+			allowed:     false,
+			transitions: []string{"closed>open", "open>half-open"},
+		},
+		{
+			name:        "closes after a successful probe",
+			steps:       []string{allow, fail, allow, fail, allow, fail, expire, allow, succeed},
+			state:       CIRCUIT_STATE_CLOSED,
+			allowed:     true,
+			transitions: []string{"closed>open", "open>half-open", "half-open>closed"},
+		},
+		{
+			name:        "reopens after a failed probe",
+			steps:       []string{allow, fail, allow, fail, allow, fail, expire, allow, fail},
+			state:       CIRCUIT_STATE_OPEN,
+			allowed:     false,
+			transitions: []string{"closed>open", "open>half-open", "half-open>open"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transitions := []string{}
+			breaker := NewCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 3, OpenTimeout: time.Minute, HalfOpenRequests: 1}, func(from string, to string) {
+				transitions = append(transitions, from+">"+to)
+			})
+
+			for i, step := range test.steps {
+				switch step {
+				case allow:
+					if err := breaker.Allow(); err != nil {
+						t.Fatalf("step %d: Allow() = %v, want nil", i, err)
+					}
+				case succeed:
+					breaker.Report(true)
+				case fail:
+					breaker.Report(false)
+				case expire:
+					breaker.openUntil = time.Now().Add(-time.Second)
+				}
+			}
+
+			if state := breaker.State(); state != test.state {
+				t.Errorf("state = %s, want %s", state, test.state)
+			}
+			if !reflect.DeepEqual(transitions, append([]string{}, test.transitions...)) {
+				t.Errorf("transitions = %v, want %v", transitions, test.transitions)
+			}
+
+			err := breaker.Allow()
+			if allowed := err == nil; allowed != test.allowed {
+				t.Errorf("allowed = %v, want %v", allowed, test.allowed)
+			}
+			if err != nil && !errors.Is(err, ErrCircuitOpen) {
+				t.Errorf("Allow() = %v, want %v", err, ErrCircuitOpen)
+			}
+		})
+	}
+}
+
+func TestCircuitBreakerCallbackReentry(t *testing.T) {
+	var breaker *CircuitBreaker
+	states := []string{}
+	breaker = NewCircuitBreaker(CircuitBreakerSettings{FailureThreshold: 1, OpenTimeout: time.Minute, HalfOpenRequests: 1}, func(from string, to string) {
+		// A callback reading the breaker, e.g. to export its state, must not deadlock
+		states = append(states, breaker.State())
+		if to == CIRCUIT_STATE_HALF_OPEN {
+			breaker.Report(true)
+		}
+	})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		breaker.Allow()
+		breaker.Report(false)
+		breaker.openUntil = time.Now().Add(-time.Second)
+		breaker.Allow()
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("breaker deadlocked when its callback used it")
+	}
+
+	if want := []string{CIRCUIT_STATE_OPEN, CIRCUIT_STATE_HALF_OPEN, CIRCUIT_STATE_CLOSED}; !reflect.DeepEqual(states, want) {
+		t.Errorf("states = %v, want %v", states, want)
+	}
+}
@@ -0,0 +1,202 @@
+package bootstrap
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	CONFIG_DEFAULT_NAME       string = "config"
+	CONFIG_DEFAULT_ENV_PREFIX string = "APP"
+
+	CONFIG_SECRET_FILE_SCHEME string = "file://"
+	CONFIG_SECRET_ENV_SCHEME  string = "env://"
+)
+
+var configPath string
+
+// SetConfigPath sets the config file or directory, it takes precedence over
+// the CONFIG_PATH environment variable. Call it before Init.
+func SetConfigPath(path string) {
+	configPath = path
+}
+
+func (c *Container) initConfig() {
+	vip, err := ReadConfig()
+	if err != nil {
+		c.logrus.Fatal(err)
+	}
+	if vip.ConfigFileUsed() == "" {
+		c.logrus.Info("config file is not found in directory")
+	}
+
+	config, err := UnmarshalConfig(vip)
+	if err != nil {
+		c.logrus.Fatal(err)
+	}
+
+	c.vip.Store(vip)
+	c.config.Store(config)
+}
+
+// ReadConfig reads config.yaml (or --config / CONFIG_PATH), merges the
+// config.<ENV>.yaml overlay next to it, lets <PREFIX>_SECTION_KEY environment
+// variables override any key and resolves file:// and env:// secret references.
+// A missing config file is not an error.
+func ReadConfig() (*viper.Viper, error) {
+	vip := viper.New()
+
+	prefix := os.Getenv("CONFIG_ENV_PREFIX")
+	if prefix == "" {
+		prefix = CONFIG_DEFAULT_ENV_PREFIX
+	}
+	vip.SetEnvPrefix(prefix)
+	vip.SetEnvKeyReplacer(strings.NewReplacer(".", "_", "-", "_"))
+	vip.AutomaticEnv()
+
+	path := configPath
+	if path == "" {
+		path = os.Getenv("CONFIG_PATH")
+	}
+
+	if info, err := os.Stat(path); path == "" || (err == nil && info.IsDir()) {
+		if path == "" {
+			path = "."
+		}
+		vip.AddConfigPath(path)
+		vip.SetConfigName(CONFIG_DEFAULT_NAME)
+	} else {
+		vip.SetConfigFile(path)
+	}
+
+	if err := vip.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+			return nil, err
+		}
+	} else if err := mergeEnvConfig(vip, os.Getenv("ENV")); err != nil {
+		return nil, err
+	}
+
+	setConfigDefaults(vip)
+	bindConfigEnv(vip, reflect.TypeOf(AppConfig{}), "")
+
+	if err := resolveSecrets(vip); err != nil {
+		return nil, err
+	}
+
+	return vip, nil
+}
+
+// mergeEnvConfig merges config.<env>.yaml over the config file that was read,
+// the overlay is optional.
+func mergeEnvConfig(vip *viper.Viper, env string) error {
+	if env == "" {
+		return nil
+	}
+
+	overlay := configOverlayPath(vip.ConfigFileUsed(), env)
+	file, err := os.Open(overlay)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := vip.MergeConfig(file); err != nil {
+		return fmt.Errorf("failed to merge %s: %w", overlay, err)
+	}
+
+	return nil
+}
+
+// configOverlayPath returns config.<env>.yaml for the config file base.
+func configOverlayPath(base string, env string) string {
+	ext := filepath.Ext(base)
+	return strings.TrimSuffix(base, ext) + "." + env + ext
+}
+
+// resolveSecrets replaces file:///path and env://NAME values with the content
+// of the file or the environment variable, including values inside lists such
+// as auth.jwt.keys.
+func resolveSecrets(vip *viper.Viper) error {
+	for _, key := range vip.AllKeys() {
+		resolved, changed, err := resolveSecret(key, vip.Get(key))
+		if err != nil {
+			return err
+		}
+		if changed {
+			vip.Set(key, resolved)
+		}
+	}
+
+	return nil
+}
+
+// resolveSecret resolves a single value, lists and maps are copied when one of
+// their values is a reference.
+func resolveSecret(key string, value interface{}) (interface{}, bool, error) {
+	switch value := value.(type) {
+	case string:
+		switch {
+		case strings.HasPrefix(value, CONFIG_SECRET_FILE_SCHEME):
+			content, err := os.ReadFile(strings.TrimPrefix(value, CONFIG_SECRET_FILE_SCHEME))
+			if err != nil {
+				return nil, false, fmt.Errorf("failed to resolve secret for %s: %w", key, err)
+			}
+			return strings.TrimRight(string(content), "\r\n"), true, nil
+		case strings.HasPrefix(value, CONFIG_SECRET_ENV_SCHEME):
+			name := strings.TrimPrefix(value, CONFIG_SECRET_ENV_SCHEME)
+			env, ok := os.LookupEnv(name)
+			if !ok {
+				return nil, false, fmt.Errorf("failed to resolve secret for %s: environment variable %s is not set", key, name)
+			}
+			return env, true, nil
+		}
+	case []interface{}:
+		var resolved []interface{}
+		for i, item := range value {
+			item, changed, err := resolveSecret(fmt.Sprintf("%s[%d]", key, i), item)
+			if err != nil {
+				return nil, false, err
+			}
+			if changed && resolved == nil {
+				resolved = append([]interface{}{}, value...)
+			}
+			if changed {
+				resolved[i] = item
+			}
+		}
+		if resolved != nil {
+			return resolved, true, nil
+		}
+	case map[string]interface{}:
+		var resolved map[string]interface{}
+		for name, item := range value {
+			item, changed, err := resolveSecret(key+"."+name, item)
+			if err != nil {
+				return nil, false, err
+			}
+			if changed && resolved == nil {
+				resolved = make(map[string]interface{}, len(value))
+				for k, v := range value {
+					resolved[k] = v
+				}
+			}
+			if changed {
+				resolved[name] = item
+			}
+		}
+		if resolved != nil {
+			return resolved, true, nil
+		}
+	}
+
+	return value, false, nil
+}
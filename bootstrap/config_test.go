@@ -0,0 +1,127 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func configTestDir(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	return dir
+}
+
+func TestInitConfigPrecedence(t *testing.T) {
+	secrets := configTestDir(t, map[string]string{"db-password": "from-file\n"})
+	dir := configTestDir(t, map[string]string{
+		"config.yaml": `
+server:
+  name: base
+  port: 8080
+  mode: debug
+database:
+  host: db.base
+  password: file://` + filepath.Join(secrets, "db-password") + `
+  username: env://CONFIG_TEST_DB_USER
+`,
+		"config.staging.yaml": `
+server:
+  port: 9090
+  mode: release
+database:
+  host: db.staging
+`,
+	})
+
+	SetConfigPath(dir)
+	t.Cleanup(func() { SetConfigPath("") })
+	t.Setenv("ENV", "staging")
+	t.Setenv("APP_SERVER_MODE", "test")
+	t.Setenv("CONFIG_TEST_DB_USER", "from-env")
+
+	vip, err := ReadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		key   string
+		value string
+	}{
+		{key: "server.name", value: "base"},
+		{key: "server.port", value: "9090"},
+		{key: "database.host", value: "db.staging"},
+		{key: "server.mode", value: "test"},
+		{key: "database.password", value: "from-file"},
+		{key: "database.username", value: "from-env"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.key, func(t *testing.T) {
+			if value := vip.GetString(test.key); value != test.value {
+				t.Errorf("%s = %q, want %q", test.key, value, test.value)
+			}
+		})
+	}
+}
+
+func TestInitConfigFile(t *testing.T) {
+	dir := configTestDir(t, map[string]string{
+		"service.yaml":      "server:\n  name: explicit\n",
+		"service.prod.yaml": "server:\n  name: overlay\n",
+		"config.yaml":       "server:\n  name: default\n",
+	})
+
+	t.Setenv("CONFIG_PATH", filepath.Join(dir, "service.yaml"))
+	t.Setenv("ENV", "prod")
+
+	vip, err := ReadConfig()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if name := vip.GetString("server.name"); name != "overlay" {
+		t.Errorf("server.name = %q, want the overlay next to the CONFIG_PATH file", name)
+	}
+}
+
+func TestResolveSecretsInLists(t *testing.T) {
+	t.Setenv("CONFIG_TEST_JWT_SECRET", "from-env")
+
+	vip := viper.New()
+	vip.Set("auth.jwt.keys", []interface{}{
+		map[string]interface{}{"kid": "k1", "secret": "env://CONFIG_TEST_JWT_SECRET"},
+		map[string]interface{}{"kid": "k2", "secret": "plain"},
+	})
+
+	if err := resolveSecrets(vip); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var keys []JwtKeyConfig
+	if err := vip.UnmarshalKey("auth.jwt.keys", &keys); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(keys) != 2 || keys[0].Secret != "from-env" || keys[1].Secret != "plain" {
+		t.Errorf("keys = %+v, want the referenced secret resolved", keys)
+	}
+}
+
+func TestResolveSecretsMissingEnv(t *testing.T) {
+	vip := viper.New()
+	vip.Set("database.password", "env://CONFIG_TEST_UNSET")
+
+	if err := resolveSecrets(vip); err == nil {
+		t.Errorf("error = nil, want the unset variable reported")
+	}
+}
@@ -0,0 +1,188 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+)
+
+// ConfigChange is published to subscribers once reloadable keys were applied.
+type ConfigChange struct {
+	Previous *AppConfig
+	Current  *AppConfig
+	Changed  []string // Keys that were applied
+}
+
+type ConfigSubscriber func(change ConfigChange)
+
+type configReloader struct {
+	mu          sync.Mutex
+	subscribers []ConfigSubscriber
+	watcher     *fsnotify.Watcher
+}
+
+// OnConfigChange registers fn to be called after every applied reload.
+func (c *Container) OnConfigChange(fn ConfigSubscriber) {
+	c.reload.mu.Lock()
+	defer c.reload.mu.Unlock()
+
+	c.reload.subscribers = append(c.reload.subscribers, fn)
+}
+
+// FeatureEnabled reports whether features.<name> is switched on.
+func (c *Container) FeatureEnabled(name string) bool {
+	return c.Config().Features[name]
+}
+
+// WatchConfig reloads the config file and its config.<ENV>.yaml overlay
+// whenever either changes. Keys tagged reload in AppConfig are swapped in at
+// once, changes to any other key are logged and ignored until the next
+// restart. An invalid file is ignored altogether.
+func (c *Container) WatchConfig() {
+	c.reload.mu.Lock()
+	defer c.reload.mu.Unlock()
+
+	base := c.GetConfig().ConfigFileUsed()
+	if c.reload.watcher != nil || base == "" {
+		return
+	}
+
+	files := []string{filepath.Clean(base)}
+	if env := os.Getenv("ENV"); env != "" {
+		files = append(files, filepath.Clean(configOverlayPath(base, env)))
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		c.logrus.Warn("config is not watched: ", err)
+		return
+	}
+
+	// The directories are watched, editors and config maps replace the files
+	dirs := map[string]bool{}
+	for _, file := range files {
+		dirs[filepath.Dir(file)] = true
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			c.logrus.Warn("config is not watched: ", err)
+			return
+		}
+	}
+
+	c.reload.watcher = watcher
+	go c.watchConfigFiles(watcher, files)
+}
+
+// watchConfigFiles reloads the config on every event about one of files, or
+// when one of them resolves to a different file as a config map update does.
+func (c *Container) watchConfigFiles(watcher *fsnotify.Watcher, files []string) {
+	targets := make(map[string]string, len(files))
+	for _, file := range files {
+		targets[file], _ = filepath.EvalSymlinks(file)
+	}
+
+	for {
+		select {
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			changed := false
+			for file, target := range targets {
+				current, _ := filepath.EvalSymlinks(file)
+				if filepath.Clean(event.Name) == file || current != target {
+					targets[file] = current
+					changed = true
+				}
+			}
+			if changed && event.Op != fsnotify.Chmod {
+				c.reloadConfig()
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			c.logrus.Warn("config watch failed: ", err)
+		}
+	}
+}
+
+func (c *Container) reloadConfig() {
+	vip, err := ReadConfig()
+	if err == nil {
+		var next *AppConfig
+		next, err = UnmarshalConfig(vip)
+		if err == nil {
+			c.applyConfig(vip, next)
+			return
+		}
+	}
+
+	c.logrus.WithField("config", c.GetConfig().ConfigFileUsed()).Warn("config reload rejected: ", err)
+}
+
+// applyConfig swaps in the reloadable keys of next, GetConfig returns vip
+// from then on.
+func (c *Container) applyConfig(vip *viper.Viper, next *AppConfig) {
+	c.reload.mu.Lock()
+
+	previous := c.Config()
+	current := *previous
+	changed, rejected := mergeReloadable(reflect.ValueOf(&current).Elem(), reflect.ValueOf(next).Elem(), "")
+
+	for _, key := range rejected {
+		c.logrus.WithField("key", key).Warn("config change requires a restart, keeping the running value")
+	}
+
+	c.vip.Store(vip)
+	if len(changed) == 0 {
+		c.reload.mu.Unlock()
+		return
+	}
+
+	c.config.Store(&current)
+	c.logrus.WithField("keys", changed).Info("config reloaded")
+
+	// Notify outside the lock, subscribers may subscribe or reload themselves
+	subscribers := append([]ConfigSubscriber{}, c.reload.subscribers...)
+	c.reload.mu.Unlock()
+
+	change := ConfigChange{Previous: previous, Current: &current, Changed: changed}
+	for _, subscriber := range subscribers {
+		subscriber(change)
+	}
+}
+
+// mergeReloadable copies the reloadable fields of next into current and
+// returns the keys it changed and the keys that differ but cannot be reloaded.
+func mergeReloadable(current reflect.Value, next reflect.Value, prefix string) (changed []string, rejected []string) {
+	for i := 0; i < current.NumField(); i++ {
+		field := current.Type().Field(i)
+		key := prefix + field.Tag.Get("mapstructure")
+
+		if reflect.DeepEqual(current.Field(i).Interface(), next.Field(i).Interface()) {
+			continue
+		}
+
+		switch {
+		case field.Tag.Get("reload") == "true":
+			current.Field(i).Set(next.Field(i))
+			changed = append(changed, key)
+		case field.Type.Kind() == reflect.Struct:
+			fieldChanged, fieldRejected := mergeReloadable(current.Field(i), next.Field(i), key+".")
+			changed = append(changed, fieldChanged...)
+			rejected = append(rejected, fieldRejected...)
+		default:
+			rejected = append(rejected, key)
+		}
+	}
+
+	return changed, rejected
+}
@@ -0,0 +1,51 @@
+package bootstrap
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const configWatchTestBase = `
+database:
+  postgres:
+    write:
+      connection: postgres://localhost/test
+    read:
+      connection: postgres://localhost/test
+custom:
+  name: base
+`
+
+func TestWatchConfigOverlay(t *testing.T) {
+	dir := configTestDir(t, map[string]string{
+		"config.yaml":      configWatchTestBase,
+		"config.test.yaml": "features:\n  beta: false\n",
+	})
+	SetConfigPath(dir)
+	t.Cleanup(func() { SetConfigPath("") })
+	t.Setenv("ENV", "test")
+
+	c := &Container{logrus: logrus.NewEntry(logrus.New())}
+	c.initConfig()
+	c.WatchConfig()
+	t.Cleanup(func() { c.Close() })
+
+	overlay := "features:\n  beta: true\ncustom:\n  name: overlay\n"
+	if err := os.WriteFile(filepath.Join(dir, "config.test.yaml"), []byte(overlay), 0o600); err != nil {
+		t.Fatalf("failed to write overlay: %v", err)
+	}
+
+	// A write may be seen as several events, wait for the last one
+	deadline := time.Now().Add(5 * time.Second)
+	for !c.FeatureEnabled("beta") || c.GetConfig().GetString("custom.name") != "overlay" {
+		if time.Now().After(deadline) {
+			t.Fatalf("features = %v, custom.name = %q, want both taken from the changed overlay",
+				c.Config().Features, c.GetConfig().GetString("custom.name"))
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -2,24 +2,42 @@ package bootstrap
 
 import (
 	"context"
+	"io"
+	"sync"
+	"sync/atomic"
 
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jinzhu/copier"
 	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
 type Container struct {
-	ctx    context.Context
-	dbw    *sqlx.DB
-	dbr    *sqlx.DB
-	pgw    *pgxpool.Pool
-	pgr    *pgxpool.Pool
-	trace  *sdktrace.TracerProvider
-	logrus *logrus.Entry
-	vip    *viper.Viper
+	ctx          context.Context
+	dbw          *sqlx.DB
+	dbr          *sqlx.DB
+	pgw          *pgxpool.Pool
+	pgr          *pgxpool.Pool
+	trace        *sdktrace.TracerProvider
+	meter        *sdkmetric.MeterProvider
+	registry     *prometheus.Registry
+	transports   sync.Map
+	validate     *validator.Validate
+	translator   *ut.UniversalTranslator
+	validateOnce sync.Once
+	health       healthRegistry
+	logrus       *logrus.Entry
+	logLevel     logLevelOverride
+	logFiles     []io.Closer
+	vip          atomic.Pointer[viper.Viper]
+	config       atomic.Pointer[AppConfig]
+	reload       configReloader
 }
 
 func Init() *Container {
@@ -31,28 +49,15 @@ func Init() *Container {
 
 	c.logrus.Debug("initialized config")
 	c.initConfig()
+	c.configureLogger()
 
 	c.logrus.Debug("initalized telemetry")
 	c.initTracer()
 
-	return c
-}
-
-func (c *Container) initConfig() {
-	vip := viper.New()
+	c.logrus.Debug("initialized metrics")
+	c.initMeter()
 
-	vip.AddConfigPath(".")
-
-	err := vip.ReadInConfig()
-	if err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-			c.logrus.Info("config file is not found in directory")
-		} else {
-			c.logrus.Fatal(err)
-		}
-	}
-
-	c.vip = vip
+	return c
 }
 
 func (c *Container) Close() error {
@@ -61,15 +66,43 @@ func (c *Container) Close() error {
 		c.trace.Shutdown(c.ctx)
 	}
 
+	if c.meter != nil {
+		c.meter.Shutdown(c.ctx)
+	}
+
+	c.reload.mu.Lock()
+	if c.reload.watcher != nil {
+		c.reload.watcher.Close()
+		c.reload.watcher = nil
+	}
+	c.reload.mu.Unlock()
+
+	for _, file := range c.logFiles {
+		file.Close()
+	}
+
 	return nil
 }
 
+// GetConfig returns the config as last read, WatchConfig swaps it on reload
+// together with Config.
 func (c *Container) GetConfig() *viper.Viper {
-	if c.vip == nil {
+	if c.vip.Load() == nil {
+		c.initConfig()
+	}
+
+	return c.vip.Load()
+}
+
+// Config returns the typed config, GetConfig stays available for keys it
+// does not cover. WatchConfig swaps it on reload, so call it again instead of
+// keeping the result around.
+func (c *Container) Config() *AppConfig {
+	if c.config.Load() == nil {
 		c.initConfig()
 	}
 
-	return c.vip
+	return c.config.Load()
 }
 
 func (c *Container) CopyStruct(from any, to any) {
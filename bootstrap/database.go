@@ -1,10 +1,10 @@
 package bootstrap
 
 import (
-	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	_ "github.com/jackc/pgx/v5/stdlib"
 	"github.com/jmoiron/sqlx"
 	"go.nhat.io/otelsql"
 	semconv "go.opentelemetry.io/otel/semconv/v1.19.0"
@@ -12,17 +12,10 @@ import (
 
 func (c *Container) Dbw() *sqlx.DB {
 	if c.dbw == nil {
-		username := c.vip.GetString("database.postgres.write.username")
-		password := c.vip.GetString("database.postgres.write.password")
-
 		var err error
-		dsn := c.vip.GetString("database.postgres.write.connection")
-
-		// Parse secret if env implemented
-		dsn = strings.Replace(dsn, "{username}", username, 1)
-		dsn = strings.Replace(dsn, "{password}", password, 1)
+		dsn := c.Config().Database.Postgres.Write.Dsn()
 
-		driverName := c.vip.GetString("database.driver")
+		driverName := c.Config().Database.Driver
 
 		if c.trace != nil {
 			driverName, err = otelsql.Register(driverName,
@@ -49,6 +42,8 @@ func (c *Container) Dbw() *sqlx.DB {
 		conn.SetConnMaxIdleTime(300 * time.Second)
 
 		c.dbw = conn
+		c.observeSqlxStats("write", c.dbw)
+		c.RegisterHealthCheck("database.sqlx.write", c.dbw.PingContext)
 	}
 
 	return c.dbw
@@ -56,17 +51,10 @@ func (c *Container) Dbw() *sqlx.DB {
 
 func (c *Container) Dbr() *sqlx.DB {
 	if c.dbr == nil {
-		username := c.vip.GetString("database.postgres.read.username")
-		password := c.vip.GetString("database.postgres.read.password")
-
 		var err error
-		dsn := c.vip.GetString("database.postgres.read.connection")
-
-		// Parse secret if env implemented
-		dsn = strings.Replace(dsn, "{username}", username, 1)
-		dsn = strings.Replace(dsn, "{password}", password, 1)
+		dsn := c.Config().Database.Postgres.Read.Dsn()
 
-		driverName := c.vip.GetString("database.driver")
+		driverName := c.Config().Database.Driver
 
 		if c.trace != nil {
 			driverName, err = otelsql.Register(driverName,
@@ -93,6 +81,8 @@ func (c *Container) Dbr() *sqlx.DB {
 		conn.SetConnMaxIdleTime(300 * time.Second)
 
 		c.dbr = conn
+		c.observeSqlxStats("read", c.dbr)
+		c.RegisterHealthCheck("database.sqlx.read", c.dbr.PingContext)
 	}
 
 	return c.dbr
@@ -100,17 +90,7 @@ func (c *Container) Dbr() *sqlx.DB {
 
 func (c *Container) Pgw() *pgxpool.Pool {
 	if c.pgw == nil {
-		username := c.vip.GetString("database.postgres.write.username")
-		password := c.vip.GetString("database.postgres.write.password")
-
-		var err error
-		dsn := c.vip.GetString("database.postgres.write.connection")
-
-		// Parse secret if env implemented
-		dsn = strings.Replace(dsn, "{username}", username, 1)
-		dsn = strings.Replace(dsn, "{password}", password, 1)
-
-		pool, err := pgxpool.New(c.ctx, dsn)
+		pool, err := pgxpool.New(c.ctx, c.Config().Database.Postgres.Write.Dsn())
 		if err != nil {
 			c.logrus.Panic(err)
 		}
@@ -120,24 +100,16 @@ func (c *Container) Pgw() *pgxpool.Pool {
 		}
 
 		c.pgw = pool
+		c.observePgxStats("write", c.pgw)
+		c.RegisterHealthCheck("database.pgx.write", c.pgw.Ping)
 	}
 
 	return c.pgw
 }
 
 func (c *Container) Pgr() *pgxpool.Pool {
-	if c.pgw == nil {
-		username := c.vip.GetString("database.postgres.read.username")
-		password := c.vip.GetString("database.postgres.read.password")
-
-		var err error
-		dsn := c.vip.GetString("database.postgres.read.connection")
-
-		// Parse secret if env implemented
-		dsn = strings.Replace(dsn, "{username}", username, 1)
-		dsn = strings.Replace(dsn, "{password}", password, 1)
-
-		pool, err := pgxpool.New(c.ctx, dsn)
+	if c.pgr == nil {
+		pool, err := pgxpool.New(c.ctx, c.Config().Database.Postgres.Read.Dsn())
 		if err != nil {
 			c.logrus.Panic(err)
 		}
@@ -147,6 +119,8 @@ func (c *Container) Pgr() *pgxpool.Pool {
 		}
 
 		c.pgr = pool
+		c.observePgxStats("read", c.pgr)
+		c.RegisterHealthCheck("database.pgx.read", c.pgr.Ping)
 	}
 
 	return c.pgr
@@ -0,0 +1,161 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	HEALTH_STATUS_UP   string = "UP"
+	HEALTH_STATUS_DOWN string = "DOWN"
+
+	HEALTH_DEFAULT_TIMEOUT   = 2 * time.Second
+	HEALTH_DEFAULT_CACHE_TTL = 5 * time.Second
+)
+
+var ErrShuttingDown = errors.New("server is shutting down")
+
+// HealthCheckFunc reports a dependency as healthy by returning nil.
+type HealthCheckFunc func(ctx context.Context) error
+
+type HealthReport struct {
+	Status     string                     `json:"status" example:"UP"`
+	Components map[string]ComponentHealth `json:"components"`
+}
+
+type ComponentHealth struct {
+	Status    string    `json:"status" example:"UP"`
+	Error     string    `json:"error,omitempty"`
+	Duration  string    `json:"duration" example:"1.2ms"`
+	CheckedAt time.Time `json:"checkedAt"`
+}
+
+type healthCheck struct {
+	check     HealthCheckFunc
+	mu        sync.Mutex
+	result    ComponentHealth
+	expiresAt time.Time
+}
+
+type healthRegistry struct {
+	mu           sync.RWMutex
+	checks       map[string]*healthCheck
+	shuttingDown bool
+}
+
+// RegisterHealthCheck adds a readiness check, registering a name again replaces it.
+func (c *Container) RegisterHealthCheck(name string, check HealthCheckFunc) {
+	c.health.mu.Lock()
+	defer c.health.mu.Unlock()
+
+	if c.health.checks == nil {
+		c.health.checks = map[string]*healthCheck{}
+	}
+	c.health.checks[name] = &healthCheck{check: check}
+}
+
+// SetShuttingDown makes readiness fail so load balancers stop routing traffic
+// while in-flight requests drain.
+func (c *Container) SetShuttingDown() {
+	c.health.mu.Lock()
+	defer c.health.mu.Unlock()
+
+	c.health.shuttingDown = true
+}
+
+// CheckHealth runs every registered check concurrently, results are cached for
+// health.cache_ttl and each check is bounded by health.timeout.
+func (c *Container) CheckHealth(ctx context.Context) HealthReport {
+	timeout := c.Config().Health.Timeout
+	ttl := c.Config().Health.CacheTTL
+
+	c.health.mu.RLock()
+	names := make([]string, 0, len(c.health.checks))
+	for name := range c.health.checks {
+		names = append(names, name)
+	}
+	shuttingDown := c.health.shuttingDown
+	c.health.mu.RUnlock()
+	sort.Strings(names)
+
+	report := HealthReport{Status: HEALTH_STATUS_UP, Components: map[string]ComponentHealth{}}
+	results := make([]ComponentHealth, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		c.health.mu.RLock()
+		check := c.health.checks[name]
+		c.health.mu.RUnlock()
+
+		wg.Add(1)
+		go func(i int, check *healthCheck) {
+			defer wg.Done()
+			results[i] = check.run(ctx, timeout, ttl)
+		}(i, check)
+	}
+	wg.Wait()
+
+	for i, name := range names {
+		report.Components[name] = results[i]
+		if results[i].Status != HEALTH_STATUS_UP {
+			report.Status = HEALTH_STATUS_DOWN
+		}
+	}
+
+	if shuttingDown {
+		report.Status = HEALTH_STATUS_DOWN
+		report.Components["shutdown"] = ComponentHealth{Status: HEALTH_STATUS_DOWN, Error: ErrShuttingDown.Error(), CheckedAt: time.Now()}
+	}
+
+	return report
+}
+
+func (h *healthCheck) run(ctx context.Context, timeout time.Duration, ttl time.Duration) ComponentHealth {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if time.Now().Before(h.expiresAt) {
+		return h.result
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- h.check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	h.result = ComponentHealth{Status: HEALTH_STATUS_UP, Duration: time.Since(start).String(), CheckedAt: start}
+	if err != nil {
+		h.result.Status = HEALTH_STATUS_DOWN
+		h.result.Error = err.Error()
+	}
+	h.expiresAt = start.Add(ttl)
+
+	return h.result
+}
+
+// dialHealthCheck checks that address accepts TCP connections.
+func dialHealthCheck(address string) HealthCheckFunc {
+	return func(ctx context.Context) error {
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+		if err != nil {
+			return err
+		}
+
+		return conn.Close()
+	}
+}
@@ -0,0 +1,76 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func healthTestContainer(health HealthConfig) *Container {
+	c := &Container{}
+	c.config.Store(&AppConfig{Health: health})
+	return c
+}
+
+func TestCheckHealth(t *testing.T) {
+	c := healthTestContainer(HealthConfig{Timeout: 50 * time.Millisecond})
+
+	var dbErr error
+	c.RegisterHealthCheck("db", func(ctx context.Context) error { return dbErr })
+	c.RegisterHealthCheck("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	report := c.CheckHealth(context.Background())
+	if report.Status != HEALTH_STATUS_DOWN || report.Components["db"].Status != HEALTH_STATUS_UP {
+		t.Errorf("report = %+v, want db up and the slow check down", report)
+	}
+	if report.Components["slow"].Error != context.DeadlineExceeded.Error() {
+		t.Errorf("slow = %+v, want it bounded by the timeout", report.Components["slow"])
+	}
+
+	dbErr = errors.New("connection refused")
+	if report = c.CheckHealth(context.Background()); report.Components["db"].Error != dbErr.Error() {
+		t.Errorf("db = %+v, want the check error", report.Components["db"])
+	}
+}
+
+func TestCheckHealthCache(t *testing.T) {
+	c := healthTestContainer(HealthConfig{Timeout: time.Second, CacheTTL: time.Minute})
+
+	calls := 0
+	c.RegisterHealthCheck("db", func(ctx context.Context) error {
+		calls++
+		return nil
+	})
+
+	c.CheckHealth(context.Background())
+	c.CheckHealth(context.Background())
+	if calls != 1 {
+		t.Errorf("calls = %d, want the second result served from the cache", calls)
+	}
+}
+
+func TestCheckHealthShuttingDown(t *testing.T) {
+	c := healthTestContainer(HealthConfig{Timeout: time.Second})
+	c.RegisterHealthCheck("db", func(ctx context.Context) error { return nil })
+
+	if report := c.CheckHealth(context.Background()); report.Status != HEALTH_STATUS_UP {
+		t.Fatalf("status = %s, want %s before shutdown", report.Status, HEALTH_STATUS_UP)
+	}
+
+	c.SetShuttingDown()
+
+	report := c.CheckHealth(context.Background())
+	if report.Status != HEALTH_STATUS_DOWN {
+		t.Errorf("status = %s, want %s while shutting down", report.Status, HEALTH_STATUS_DOWN)
+	}
+	if report.Components["shutdown"].Error != ErrShuttingDown.Error() {
+		t.Errorf("shutdown = %+v, want %v", report.Components["shutdown"], ErrShuttingDown)
+	}
+	if report.Components["db"].Status != HEALTH_STATUS_UP {
+		t.Errorf("db = %+v, want dependencies still reported", report.Components["db"])
+	}
+}
@@ -3,24 +3,29 @@ package bootstrap
 import (
 	"bytes"
 	"context"
-	"crypto/tls"
+	"fmt"
+	"gogin-template/baselib/identifier"
 	"io"
 	"net/http"
 	"net/http/httptrace"
+	neturl "net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"github.com/spf13/viper"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/httptrace/otelhttptrace"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
-	"go.opentelemetry.io/contrib/propagators/b3"
 	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
 )
 
 type WithHttpContext func(r *http.Request)
 
+type HttpClientOption func(client *HttpClient)
+
 const (
 	HTTP_METHOD_GET    string = "GET"
 	HTTP_METHOD_POST   string = "POST"
@@ -29,25 +34,181 @@ const (
 )
 
 type HttpClient struct {
-	Client *http.Client
-	trace  trace.Tracer
-	cfg    *Container
+	Client    *http.Client
+	name      string
+	trace     trace.Tracer
+	cfg       *Container
+	metrics   httpClientMetrics
+	retry     RetryPolicy
+	breaker   *CircuitBreakerSettings
+	breakers  sync.Map
+	healthUrl string
+}
+
+// WithRetryPolicy retries failed calls according to policy.
+func WithRetryPolicy(policy RetryPolicy) HttpClientOption {
+	return func(client *HttpClient) {
+		client.retry = policy
+	}
+}
+
+// WithCircuitBreaker guards every downstream host with its own circuit breaker.
+func WithCircuitBreaker(settings CircuitBreakerSettings) HttpClientOption {
+	return func(client *HttpClient) {
+		client.breaker = &settings
+	}
+}
+
+// WithHealthCheck adds the downstream to readiness, it is healthy while url
+// answers with a status below 500.
+func WithHealthCheck(url string) HttpClientOption {
+	return func(client *HttpClient) {
+		client.healthUrl = url
+	}
+}
+
+// WithClientConfig applies the retry, circuit_breaker, tls and health_url settings of
+// client_configuration.clients.<name>, sections that are absent stay disabled.
+func WithClientConfig(name string) HttpClientOption {
+	return func(client *HttpClient) {
+		client.name = name
+		config := client.cfg.Config().ClientConfiguration.Clients[name]
+		if config.Retry != nil {
+			client.retry = *config.Retry
+		}
+		if config.CircuitBreaker != nil {
+			settings := *config.CircuitBreaker
+			client.breaker = &settings
+		}
+		if config.HealthUrl != "" {
+			client.healthUrl = config.HealthUrl
+		}
+	}
+}
+
+type httpClientMetrics struct {
+	requests metric.Int64Counter
+	failures metric.Int64Counter
+	duration metric.Float64Histogram
 }
 
-func NewHttpClient(cfg *Container, trace trace.Tracer) *HttpClient {
-	propagate := otelhttp.WithPropagators(b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader | b3.B3SingleHeader)))
+func NewHttpClient(cfg *Container, trace trace.Tracer, opts ...HttpClientOption) *HttpClient {
+	httpClient := &HttpClient{cfg: cfg, trace: trace, metrics: newHttpClientMetrics(cfg)}
+	for _, o := range opts {
+		o(httpClient)
+	}
 
-	timeout := viper.GetInt("client_configuration.timeout")
-	client := &http.Client{
+	timeout := cfg.Config().ClientConfiguration.Timeout
+	httpClient.Client = &http.Client{
 		Timeout:   time.Duration(timeout) * time.Second,
-		Transport: otelhttp.NewTransport(http.DefaultTransport, propagate),
+		Transport: otelhttp.NewTransport(cfg.Transport(httpClient.name)),
+	}
+
+	if httpClient.healthUrl != "" {
+		cfg.RegisterHealthCheck("http."+httpClient.healthName(), httpClient.checkHealth)
+	}
+
+	return httpClient
+}
+
+func (client *HttpClient) healthName() string {
+	if client.name != "" {
+		return client.name
+	}
+
+	if u, err := neturl.Parse(client.healthUrl); err == nil {
+		return u.Host
+	}
+
+	return client.healthUrl
+}
+
+func (client *HttpClient) checkHealth(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, client.healthUrl, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := client.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, res.Body)
+
+	if res.StatusCode >= http.StatusInternalServerError {
+		return fmt.Errorf("%s responded %s", client.healthUrl, res.Status)
+	}
+
+	return nil
+}
+
+// circuitBreaker returns the breaker of host, or nil when the client has none.
+func (client *HttpClient) circuitBreaker(host string) *CircuitBreaker {
+	if client.breaker == nil {
+		return nil
+	}
+
+	if breaker, ok := client.breakers.Load(host); ok {
+		return breaker.(*CircuitBreaker)
+	}
+
+	breaker, _ := client.breakers.LoadOrStore(host, NewCircuitBreaker(*client.breaker, func(from string, to string) {
+		client.cfg.Logger().Warn("HTTPCLIENT CIRCUIT BREAKER: ", host, ": ", from, " -> ", to)
+	}))
+
+	return breaker.(*CircuitBreaker)
+}
+
+func newHttpClientMetrics(cfg *Container) httpClientMetrics {
+	meter := cfg.GetMeter()
+	metrics := httpClientMetrics{}
+
+	var err error
+	metrics.requests, err = meter.Int64Counter("http.client.requests",
+		metric.WithDescription("Number of outbound HTTP requests"))
+	if err != nil {
+		cfg.Logger().Error(err)
+	}
+
+	metrics.failures, err = meter.Int64Counter("http.client.errors",
+		metric.WithDescription("Number of outbound HTTP requests that failed or returned a 5xx status"))
+	if err != nil {
+		cfg.Logger().Error(err)
+	}
+
+	metrics.duration, err = meter.Float64Histogram("http.client.request.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of outbound HTTP requests"),
+		metric.WithExplicitBucketBoundaries(0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10))
+	if err != nil {
+		cfg.Logger().Error(err)
+	}
+
+	return metrics
+}
+
+func (m httpClientMetrics) record(ctx context.Context, req *http.Request, res *http.Response, err error, elapsed time.Duration) {
+	status := "error"
+	if res != nil {
+		status = strconv.Itoa(res.StatusCode)
 	}
 
-	return &HttpClient{cfg: cfg, Client: client, trace: trace}
+	attrs := metric.WithAttributes(
+		attribute.String("http.method", req.Method),
+		attribute.String("server.address", req.URL.Host),
+		attribute.String("http.status_code", status),
+	)
+
+	m.requests.Add(ctx, 1, attrs)
+	m.duration.Record(ctx, elapsed.Seconds(), attrs)
+	if err != nil || res.StatusCode >= http.StatusInternalServerError {
+		m.failures.Add(ctx, 1, attrs)
+	}
 }
 
 func (client *HttpClient) RequestWithLog(ctx context.Context, method, url string, body string, opts ...WithHttpContext) (int, string, string, error) {
-	client.cfg.Logger().Info("HTTPCLIENT REQUEST: ", method, " ", url, ": ", string(body))
+	client.cfg.Log(ctx).Info("HTTPCLIENT REQUEST: ", method, " ", url, ": ", string(body))
 
 	res, err := client.Request(ctx, method, url, bytes.NewReader([]byte(body)), opts...)
 	if err != nil {
@@ -63,7 +224,7 @@ func (client *HttpClient) RequestWithLog(ctx context.Context, method, url string
 		}
 		resBody = resBuf.String()
 	}
-	client.cfg.Logger().Info("HTTPCLIENT RESPONSE: ", method, " ", url, ": ", resBody)
+	client.cfg.Log(ctx).Info("HTTPCLIENT RESPONSE: ", method, " ", url, ": ", resBody)
 
 	return res.StatusCode, res.Status, resBody, err
 }
@@ -87,18 +248,100 @@ func (client *HttpClient) Request(ctx context.Context, method, url string, body
 		o(req)
 	}
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+	// Forward the correlation id so the downstream logs the same reference.
+	if headers := client.cfg.Config().Server.Correlation.Headers; len(headers) > 0 && req.Header.Get(headers[0]) == "" {
+		if logReff, ok := identifier.LookupLogReff(ctx); ok {
+			req.Header.Set(headers[0], logReff)
+		}
+	}
+
+	retry := client.retry.allows(req)
+	var payload []byte
+	if retry && req.Body != nil && req.GetBody == nil {
+		payload, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(payload)), nil
+		}
+	}
+
+	breaker := client.circuitBreaker(req.URL.Host)
+
+	var res *http.Response
+	attempt := 0
+	for {
+		attempt++
+		if attempt > 1 && req.GetBody != nil {
+			req.Body, err = req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		res, err = client.do(ctx, breaker, req)
+		if !retry || attempt >= client.retry.MaxAttempts || !client.retry.shouldRetry(res, err) {
+			break
+		}
+
+		wait, ok := client.retry.backoff(attempt, res)
+		if !ok {
+			break
+		}
+
+		if res != nil {
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		span.AddEvent("retry", trace.WithAttributes(
+			attribute.Int("http.retry.attempt", attempt+1),
+			attribute.String("http.retry.backoff", wait.String()),
+		))
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	span.SetAttributes(attribute.Int("http.attempts", attempt))
+	if breaker != nil {
+		span.SetAttributes(attribute.String("http.circuit_breaker.state", breaker.State()))
+	}
+
+	if err != nil {
+		span.SetStatus(codes.Error, err.Error())
+		span.RecordError(err)
+	} else if res.StatusCode >= http.StatusInternalServerError {
+		span.SetStatus(codes.Error, res.Status)
 	}
-	client.Client.Transport = tr
-	res, err := client.Client.Do(req.WithContext(ctx))
-	if err != nil && res != nil {
-		if res.StatusCode >= 400 {
-			span.SetStatus(codes.Error, err.Error())
-			span.RecordError(err)
+
+	return res, err
+}
+
+// do sends a single attempt through the circuit breaker of the host.
+func (client *HttpClient) do(ctx context.Context, breaker *CircuitBreaker, req *http.Request) (*http.Response, error) {
+	if breaker != nil {
+		if err := breaker.Allow(); err != nil {
+			trace.SpanFromContext(ctx).AddEvent("circuit breaker rejected call",
+				trace.WithAttributes(attribute.String("http.circuit_breaker.state", breaker.State())))
+			return nil, err
 		}
 	}
 
+	start := time.Now()
+	res, err := client.Client.Do(req)
+	client.metrics.record(ctx, req, res, err, time.Since(start))
+
+	if breaker != nil {
+		breaker.Report(err == nil && res.StatusCode < http.StatusInternalServerError)
+	}
+
 	return res, err
 }
 
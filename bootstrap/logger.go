@@ -2,11 +2,38 @@ package bootstrap
 
 import (
 	"context"
+	"io"
 	"os"
+	"sync"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"gopkg.in/natefinch/lumberjack.v2"
 )
 
+const (
+	LOG_FORMAT_JSON   string = "json"
+	LOG_FORMAT_TEXT   string = "text"
+	LOG_FORMAT_LOGFMT string = "logfmt"
+
+	LOG_OUTPUT_STDOUT string = "stdout"
+	LOG_OUTPUT_STDERR string = "stderr"
+	LOG_OUTPUT_FILE   string = "file"
+)
+
+type loggerCtxKey struct{}
+
+type logLevelOverride struct {
+	mu        sync.Mutex
+	timer     *time.Timer
+	expiresAt *time.Time
+
+	// generation counts the changes, a timer only reverts the change it was
+	// started for even when Stop came too late to keep it from firing
+	generation uint64
+}
+
 func (c *Container) initLogger() {
 	logger := logrus.New()
 	logger.SetFormatter(&logrus.JSONFormatter{})
@@ -16,10 +43,145 @@ func (c *Container) initLogger() {
 	c.logrus = logger.WithContext(context.Background())
 }
 
+// configureLogger applies log.level, log.format and log.outputs once the
+// config has been read, the level follows config reloads.
+func (c *Container) configureLogger() {
+	config := c.Config().Log
+	logger := c.logrus.Logger
+
+	switch config.Format {
+	case LOG_FORMAT_TEXT:
+		logger.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	case LOG_FORMAT_LOGFMT:
+		logger.SetFormatter(&logrus.TextFormatter{DisableColors: true, FullTimestamp: true})
+	default:
+		logger.SetFormatter(&logrus.JSONFormatter{})
+	}
+
+	writers := []io.Writer{}
+	for _, output := range config.Outputs {
+		switch output.Type {
+		case LOG_OUTPUT_STDERR:
+			writers = append(writers, os.Stderr)
+		case LOG_OUTPUT_FILE:
+			file := &lumberjack.Logger{
+				Filename:   output.Path,
+				MaxSize:    output.MaxSize,
+				MaxAge:     output.MaxAge,
+				MaxBackups: output.MaxBackups,
+				Compress:   output.Compress,
+			}
+			c.logFiles = append(c.logFiles, file)
+			writers = append(writers, file)
+		default:
+			writers = append(writers, os.Stdout)
+		}
+	}
+	if len(writers) == 0 {
+		writers = append(writers, os.Stdout)
+	}
+	logger.SetOutput(io.MultiWriter(writers...))
+
+	c.SetLogLevel(c.configuredLogLevel(), 0)
+	c.OnConfigChange(func(change ConfigChange) {
+		if change.Previous.Log.Level != change.Current.Log.Level {
+			c.SetLogLevel(c.configuredLogLevel(), 0)
+		}
+	})
+}
+
+func (c *Container) configuredLogLevel() logrus.Level {
+	level, err := logrus.ParseLevel(c.Config().Log.Level)
+	if err != nil {
+		return logrus.InfoLevel
+	}
+
+	return level
+}
+
+// SetLogLevel changes the log level at runtime. With a positive duration the
+// level goes back to log.level once it passes, otherwise it is kept until the
+// next change.
+func (c *Container) SetLogLevel(level logrus.Level, duration time.Duration) {
+	c.logLevel.mu.Lock()
+	defer c.logLevel.mu.Unlock()
+
+	if c.logLevel.timer != nil {
+		c.logLevel.timer.Stop()
+		c.logLevel.timer = nil
+	}
+	c.logLevel.expiresAt = nil
+	c.logLevel.generation++
+
+	c.logrus.Logger.SetLevel(level)
+	if duration <= 0 {
+		return
+	}
+
+	generation := c.logLevel.generation
+	expiresAt := time.Now().Add(duration)
+	c.logLevel.expiresAt = &expiresAt
+	c.logLevel.timer = time.AfterFunc(duration, func() {
+		c.expireLogLevel(generation)
+	})
+}
+
+// expireLogLevel restores log.level when the temporary change of generation
+// is still the current one.
+func (c *Container) expireLogLevel(generation uint64) {
+	c.logLevel.mu.Lock()
+	defer c.logLevel.mu.Unlock()
+
+	if c.logLevel.generation != generation {
+		return
+	}
+
+	c.logLevel.timer = nil
+	c.logLevel.expiresAt = nil
+	c.logrus.Logger.SetLevel(c.configuredLogLevel())
+	c.logrus.WithField("logLevel", c.Config().Log.Level).Info("temporary log level expired")
+}
+
+// LogLevel returns the current level and, when it was set temporarily, the
+// time it reverts to log.level.
+func (c *Container) LogLevel() (logrus.Level, *time.Time) {
+	c.logLevel.mu.Lock()
+	defer c.logLevel.mu.Unlock()
+
+	return c.logrus.Logger.GetLevel(), c.logLevel.expiresAt
+}
+
 func (c *Container) Logger() *logrus.Entry {
 	return c.logrus
 }
 
+// ContextWithLogger returns a copy of ctx carrying logger, see Log.
+func ContextWithLogger(ctx context.Context, logger *logrus.Entry) context.Context {
+	return context.WithValue(ctx, loggerCtxKey{}, logger)
+}
+
+// Log returns the logger of the request ctx belongs to, which LoggingMiddleware
+// tags with its logReff, traceId, route and user. spanId follows the span
+// active in ctx. Outside of a request it is Logger with the trace of ctx.
+func (c *Container) Log(ctx context.Context) *logrus.Entry {
+	logger, ok := ctx.Value(loggerCtxKey{}).(*logrus.Entry)
+	if !ok {
+		logger = c.logrus
+	}
+
+	span := trace.SpanContextFromContext(ctx)
+	if !span.IsValid() {
+		return logger
+	}
+	if !ok {
+		logger = logger.WithField("traceId", span.TraceID().String())
+	}
+
+	return logger.WithField("spanId", span.SpanID().String())
+}
+
+// UpdateLogger replaces the process wide logger, it is meant for subcommands
+// to tag their logs at startup. Use ContextWithLogger for anything narrower.
 func (c *Container) UpdateLogger(updatedLogger *logrus.Entry) {
 	c.logrus = updatedLogger
 }
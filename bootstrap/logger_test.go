@@ -0,0 +1,67 @@
+package bootstrap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func loggerTestContainer(level string) *Container {
+	c := &Container{logrus: logrus.NewEntry(logrus.New())}
+	c.config.Store(&AppConfig{Log: LogConfig{Level: level}})
+	return c
+}
+
+func TestSetLogLevelExpires(t *testing.T) {
+	c := loggerTestContainer("info")
+
+	c.SetLogLevel(logrus.DebugLevel, 20*time.Millisecond)
+	if level, expiresAt := c.LogLevel(); level != logrus.DebugLevel || expiresAt == nil {
+		t.Fatalf("level = %s, expiresAt = %v, want debug until it expires", level, expiresAt)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		level, expiresAt := c.LogLevel()
+		if level == logrus.InfoLevel && expiresAt == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("level = %s, expiresAt = %v, want log.level restored", level, expiresAt)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestSetLogLevelKeepsPermanentChange(t *testing.T) {
+	c := loggerTestContainer("info")
+
+	c.SetLogLevel(logrus.DebugLevel, 20*time.Millisecond)
+	c.SetLogLevel(logrus.WarnLevel, 0)
+	time.Sleep(50 * time.Millisecond)
+
+	if level, expiresAt := c.LogLevel(); level != logrus.WarnLevel || expiresAt != nil {
+		t.Errorf("level = %s, expiresAt = %v, want warn kept after the earlier timer", level, expiresAt)
+	}
+}
+
+func TestSetLogLevelIgnoresStaleTimer(t *testing.T) {
+	c := loggerTestContainer("info")
+
+	c.SetLogLevel(logrus.DebugLevel, time.Hour)
+	stale := c.logLevel.generation
+	c.SetLogLevel(logrus.WarnLevel, time.Hour)
+
+	// A timer that fired while the second change waited for the lock
+	c.expireLogLevel(stale)
+
+	if level, expiresAt := c.LogLevel(); level != logrus.WarnLevel || expiresAt == nil {
+		t.Errorf("level = %s, expiresAt = %v, want the newer temporary warn kept", level, expiresAt)
+	}
+
+	c.expireLogLevel(c.logLevel.generation)
+	if level, expiresAt := c.LogLevel(); level != logrus.InfoLevel || expiresAt != nil {
+		t.Errorf("level = %s, expiresAt = %v, want log.level restored by the current timer", level, expiresAt)
+	}
+}
@@ -0,0 +1,128 @@
+package bootstrap
+
+import (
+	"context"
+	"net/http"
+	"os"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jmoiron/sqlx"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+)
+
+func (c *Container) GetMeter() metric.Meter {
+	return otel.Meter(os.Getenv("SERVICE_NAME"))
+}
+
+// MetricsHandler serves the Prometheus registry, it responds 404 when metrics are disabled.
+func (c *Container) MetricsHandler() http.Handler {
+	if c.registry == nil {
+		return http.NotFoundHandler()
+	}
+
+	return promhttp.HandlerFor(c.registry, promhttp.HandlerOpts{Registry: c.registry})
+}
+
+func (c *Container) initMeter() *sdkmetric.MeterProvider {
+	if !c.Config().Telemetry.Metrics.Enable {
+		c.logrus.Debug("metrics disabled")
+		return nil
+	}
+
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+
+	exporter, err := otelprometheus.New(otelprometheus.WithRegisterer(registry))
+	if err != nil {
+		c.logrus.Fatal(err)
+	}
+
+	c.registry = registry
+	c.meter = sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(exporter),
+		sdkmetric.WithResource(c.telemetryResource()),
+	)
+
+	otel.SetMeterProvider(c.meter)
+
+	return c.meter
+}
+
+// observeSqlxStats publishes the database/sql pool statistics of conn.
+func (c *Container) observeSqlxStats(pool string, conn *sqlx.DB) {
+	attrs := metric.WithAttributes(attribute.String("db.pool", pool), attribute.String("db.client", "sqlx"))
+
+	c.observePool(func(ctx context.Context, gauges poolGauges, o metric.Observer) {
+		stats := conn.Stats()
+		o.ObserveInt64(gauges.open, int64(stats.OpenConnections), attrs)
+		o.ObserveInt64(gauges.inUse, int64(stats.InUse), attrs)
+		o.ObserveInt64(gauges.idle, int64(stats.Idle), attrs)
+		o.ObserveInt64(gauges.max, int64(stats.MaxOpenConnections), attrs)
+		o.ObserveInt64(gauges.waits, stats.WaitCount, attrs)
+		o.ObserveFloat64(gauges.waitTime, stats.WaitDuration.Seconds(), attrs)
+	})
+}
+
+// observePgxStats publishes the pgxpool statistics of pool.
+func (c *Container) observePgxStats(pool string, conn *pgxpool.Pool) {
+	attrs := metric.WithAttributes(attribute.String("db.pool", pool), attribute.String("db.client", "pgx"))
+
+	c.observePool(func(ctx context.Context, gauges poolGauges, o metric.Observer) {
+		stats := conn.Stat()
+		o.ObserveInt64(gauges.open, int64(stats.TotalConns()), attrs)
+		o.ObserveInt64(gauges.inUse, int64(stats.AcquiredConns()), attrs)
+		o.ObserveInt64(gauges.idle, int64(stats.IdleConns()), attrs)
+		o.ObserveInt64(gauges.max, int64(stats.MaxConns()), attrs)
+		o.ObserveInt64(gauges.waits, stats.EmptyAcquireCount(), attrs)
+		o.ObserveFloat64(gauges.waitTime, stats.AcquireDuration().Seconds(), attrs)
+	})
+}
+
+type poolGauges struct {
+	open     metric.Int64ObservableGauge
+	inUse    metric.Int64ObservableGauge
+	idle     metric.Int64ObservableGauge
+	max      metric.Int64ObservableGauge
+	waits    metric.Int64ObservableCounter
+	waitTime metric.Float64ObservableCounter
+}
+
+func (c *Container) observePool(observe func(ctx context.Context, gauges poolGauges, o metric.Observer)) {
+	if c.meter == nil {
+		return
+	}
+
+	meter := c.GetMeter()
+	gauges := poolGauges{}
+	var errs [6]error
+	gauges.open, errs[0] = meter.Int64ObservableGauge("db.client.connections.open", metric.WithDescription("Open connections in the pool"))
+	gauges.inUse, errs[1] = meter.Int64ObservableGauge("db.client.connections.in_use", metric.WithDescription("Connections currently in use"))
+	gauges.idle, errs[2] = meter.Int64ObservableGauge("db.client.connections.idle", metric.WithDescription("Idle connections in the pool"))
+	gauges.max, errs[3] = meter.Int64ObservableGauge("db.client.connections.max", metric.WithDescription("Maximum connections allowed in the pool"))
+	gauges.waits, errs[4] = meter.Int64ObservableCounter("db.client.connections.waits", metric.WithDescription("Times a caller had to wait for a connection"))
+	gauges.waitTime, errs[5] = meter.Float64ObservableCounter("db.client.connections.wait_time", metric.WithUnit("s"), metric.WithDescription("Total time spent waiting for a connection"))
+	for _, err := range errs {
+		if err != nil {
+			c.logrus.Error(err)
+			return
+		}
+	}
+
+	_, err := meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+		observe(ctx, gauges, o)
+		return nil
+	}, gauges.open, gauges.inUse, gauges.idle, gauges.max, gauges.waits, gauges.waitTime)
+	if err != nil {
+		c.logrus.Error(err)
+	}
+}
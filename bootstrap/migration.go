@@ -0,0 +1,309 @@
+package bootstrap
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	MIGRATION_DEFAULT_DIR   string = "migrations"
+	MIGRATION_DEFAULT_TABLE string = "public.schema_migrations"
+	MIGRATION_VERSION_TIME  string = "20060102150405"
+)
+
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_([a-zA-Z0-9_]+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	UpPath   string
+	DownPath string
+}
+
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type Migrator struct {
+	cfg   *Container
+	dir   string
+	table string
+}
+
+func NewMigrator(cfg *Container, dir string) *Migrator {
+	if dir == "" {
+		dir = cfg.Config().Database.Migration.Dir
+	}
+
+	return &Migrator{cfg: cfg, dir: dir, table: cfg.Config().Database.Migration.Table}
+}
+
+// Load reads the migration directory and returns the migrations ordered by version.
+func (m *Migrator) Load() ([]Migration, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		migration, ok := migrations[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			migrations[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d is used by both %s and %s", version, migration.Name, match[2])
+		}
+
+		path := filepath.Join(m.dir, entry.Name())
+		if match[3] == "up" {
+			migration.UpPath = path
+		} else {
+			migration.DownPath = path
+		}
+	}
+
+	result := make([]Migration, 0, len(migrations))
+	for _, migration := range migrations {
+		if migration.UpPath == "" {
+			return nil, fmt.Errorf("migration %d_%s has no up file", migration.Version, migration.Name)
+		}
+		result = append(result, *migration)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+
+	return result, nil
+}
+
+// Up applies at most steps pending migrations, or all of them when steps is not positive.
+func (m *Migrator) Up(ctx context.Context, steps int) error {
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		migrations, err := m.Load()
+		if err != nil {
+			return err
+		}
+
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		for _, migration := range migrations {
+			if _, ok := applied[migration.Version]; ok {
+				continue
+			}
+			if steps > 0 && count >= steps {
+				break
+			}
+
+			m.cfg.Logger().Infof("applying migration %d_%s", migration.Version, migration.Name)
+			err = m.run(ctx, conn, migration.UpPath, `INSERT INTO `+m.table+` (version, name) VALUES ($1, $2)`, migration.Version, migration.Name)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+
+		m.cfg.Logger().Infof("applied %d migration(s)", count)
+		return nil
+	})
+}
+
+// Down reverts the latest steps applied migrations, defaulting to one.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	if steps <= 0 {
+		steps = 1
+	}
+
+	return m.withLock(ctx, func(conn *sqlx.Conn) error {
+		migrations, err := m.Load()
+		if err != nil {
+			return err
+		}
+
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		count := 0
+		for i := len(migrations) - 1; i >= 0 && count < steps; i-- {
+			migration := migrations[i]
+			if _, ok := applied[migration.Version]; !ok {
+				continue
+			}
+			if migration.DownPath == "" {
+				return fmt.Errorf("migration %d_%s has no down file", migration.Version, migration.Name)
+			}
+
+			m.cfg.Logger().Infof("reverting migration %d_%s", migration.Version, migration.Name)
+			err = m.run(ctx, conn, migration.DownPath, `DELETE FROM `+m.table+` WHERE version = $1`, migration.Version)
+			if err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			count++
+		}
+
+		m.cfg.Logger().Infof("reverted %d migration(s)", count)
+		return nil
+	})
+}
+
+// Status lists every known migration along with the time it was applied, if any.
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	var result []MigrationStatus
+
+	err := m.withLock(ctx, func(conn *sqlx.Conn) error {
+		migrations, err := m.Load()
+		if err != nil {
+			return err
+		}
+
+		applied, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			status := MigrationStatus{Migration: migration}
+			if appliedAt, ok := applied[migration.Version]; ok {
+				status.AppliedAt = &appliedAt
+			}
+			result = append(result, status)
+		}
+
+		return nil
+	})
+
+	return result, err
+}
+
+// Create writes an empty up/down pair versioned with the current UTC timestamp.
+func (m *Migrator) Create(name string) (*Migration, error) {
+	name = strings.Trim(regexp.MustCompile(`[^a-zA-Z0-9]+`).ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, fmt.Errorf("migration name is required")
+	}
+
+	if err := os.MkdirAll(m.dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	version, _ := strconv.ParseInt(time.Now().UTC().Format(MIGRATION_VERSION_TIME), 10, 64)
+	base := filepath.Join(m.dir, fmt.Sprintf("%d_%s", version, name))
+	migration := &Migration{
+		Version:  version,
+		Name:     name,
+		UpPath:   base + ".up.sql",
+		DownPath: base + ".down.sql",
+	}
+
+	if err := os.WriteFile(migration.UpPath, []byte("-- "+name+" (up)\n"), 0o644); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(migration.DownPath, []byte("-- "+name+" (down)\n"), 0o644); err != nil {
+		return nil, err
+	}
+
+	return migration, nil
+}
+
+// withLock runs fn on a single connection holding a session advisory lock, so
+// that concurrent migrators started by parallel pods are serialized.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sqlx.Conn) error) error {
+	conn, err := m.cfg.Dbw().Connx(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	m.cfg.Logger().Debugf("acquiring migration lock on %s", m.table)
+	if _, err := conn.ExecContext(ctx, `SELECT pg_advisory_lock(hashtext($1))`, m.table); err != nil {
+		return err
+	}
+	defer conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock(hashtext($1))`, m.table)
+
+	_, err = conn.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+m.table+` (
+		version bigint PRIMARY KEY,
+		name text NOT NULL,
+		applied_at timestamptz NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return err
+	}
+
+	return fn(conn)
+}
+
+func (m *Migrator) applied(ctx context.Context, conn *sqlx.Conn) (map[int64]time.Time, error) {
+	rows, err := conn.QueryxContext(ctx, `SELECT version, applied_at FROM `+m.table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := map[int64]time.Time{}
+	for rows.Next() {
+		var version int64
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		result[version] = appliedAt
+	}
+
+	return result, rows.Err()
+}
+
+func (m *Migrator) run(ctx context.Context, conn *sqlx.Conn, path string, bookkeeping string, args ...interface{}) error {
+	script, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTxx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if strings.TrimSpace(string(script)) != "" {
+		if _, err := tx.ExecContext(ctx, string(script)); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, bookkeeping, args...); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
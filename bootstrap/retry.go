@@ -0,0 +1,116 @@
+package bootstrap
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how HttpClient retries a failed call. A zero policy
+// (MaxAttempts <= 1) disables retries.
+type RetryPolicy struct {
+	MaxAttempts        int           `mapstructure:"max_attempts" validate:"min=0"`            // Total attempts, including the first one
+	InitialBackoff     time.Duration `mapstructure:"initial_backoff" validate:"min=0"`         // Backoff ceiling before the second attempt
+	MaxBackoff         time.Duration `mapstructure:"max_backoff" validate:"min=0"`             // Upper bound of any backoff, Retry-After above it stops retrying
+	Multiplier         float64       `mapstructure:"multiplier" validate:"gte=1"`              // Growth of the backoff ceiling per attempt
+	RetryNonIdempotent bool          `mapstructure:"retry_non_idempotent"`                     // Also retry POST / PATCH without an Idempotency-Key header
+	RetryOn            []int         `mapstructure:"retry_on" validate:"dive,min=400,max=599"` // Status codes worth retrying
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     2 * time.Second,
+		Multiplier:     2,
+		RetryOn: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+func (p RetryPolicy) enabled() bool {
+	return p.MaxAttempts > 1
+}
+
+// allows reports whether req may be sent more than once.
+func (p RetryPolicy) allows(req *http.Request) bool {
+	if !p.enabled() {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return p.RetryNonIdempotent || req.Header.Get("Idempotency-Key") != ""
+}
+
+func (p RetryPolicy) shouldRetry(res *http.Response, err error) bool {
+	if err != nil {
+		return !errors.Is(err, ErrCircuitOpen)
+	}
+
+	for _, code := range p.RetryOn {
+		if res.StatusCode == code {
+			return true
+		}
+	}
+
+	return false
+}
+
+// backoff returns how long to wait before the next attempt, using full jitter
+// unless the server asked for a specific delay through Retry-After.
+func (p RetryPolicy) backoff(attempt int, res *http.Response) (time.Duration, bool) {
+	if res != nil {
+		if wait, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			if p.MaxBackoff > 0 && wait > p.MaxBackoff {
+				return 0, false
+			}
+			return wait, true
+		}
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	ceiling := float64(p.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if p.MaxBackoff > 0 && ceiling > float64(p.MaxBackoff) {
+		ceiling = float64(p.MaxBackoff)
+	}
+	if ceiling <= 0 {
+		return 0, true
+	}
+
+	return time.Duration(rand.Int63n(int64(ceiling) + 1)), true
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
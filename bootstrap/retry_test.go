@@ -0,0 +1,134 @@
+package bootstrap
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		min   time.Duration
+		max   time.Duration
+		ok    bool
+	}{
+		{name: "empty", value: "", ok: false},
+		{name: "seconds", value: "3", min: 3 * time.Second, max: 3 * time.Second, ok: true},
+		{name: "zero seconds", value: "0", min: 0, max: 0, ok: true},
+		{name: "negative seconds", value: "-1", ok: false},
+		{name: "fraction", value: "1.5", ok: false},
+		{name: "garbage", value: "soon", ok: false},
+		{name: "future date", value: time.Now().Add(time.Minute).UTC().Format(http.TimeFormat), min: 58 * time.Second, max: time.Minute, ok: true},
+		{name: "past date", value: time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat), min: 0, max: 0, ok: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			wait, ok := parseRetryAfter(test.value)
+			if ok != test.ok {
+				t.Fatalf("ok = %v, want %v", ok, test.ok)
+			}
+			if wait < test.min || wait > test.max {
+				t.Errorf("wait = %s, want between %s and %s", wait, test.min, test.max)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: 100 * time.Millisecond, MaxBackoff: time.Second, Multiplier: 2}
+
+	tests := []struct {
+		name       string
+		attempt    int
+		retryAfter string
+		max        time.Duration
+		ok         bool
+	}{
+		{name: "first attempt", attempt: 1, max: 100 * time.Millisecond, ok: true},
+		{name: "grows per attempt", attempt: 3, max: 400 * time.Millisecond, ok: true},
+		{name: "capped by max backoff", attempt: 10, max: time.Second, ok: true},
+		{name: "retry after within max backoff", attempt: 1, retryAfter: "1", max: time.Second, ok: true},
+		{name: "retry after above max backoff", attempt: 1, retryAfter: "5", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			res := &http.Response{Header: http.Header{}}
+			if test.retryAfter != "" {
+				res.Header.Set("Retry-After", test.retryAfter)
+			}
+
+			wait, ok := policy.backoff(test.attempt, res)
+			if ok != test.ok {
+				t.Fatalf("ok = %v, want %v", ok, test.ok)
+			}
+			if wait < 0 || wait > test.max {
+				t.Errorf("wait = %s, want at most %s", wait, test.max)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyAllows(t *testing.T) {
+	tests := []struct {
+		name   string
+		policy RetryPolicy
+		method string
+		key    string
+		want   bool
+	}{
+		{name: "disabled", policy: RetryPolicy{MaxAttempts: 1}, method: http.MethodGet, want: false},
+		{name: "idempotent method", policy: RetryPolicy{MaxAttempts: 3}, method: http.MethodPut, want: true},
+		{name: "post", policy: RetryPolicy{MaxAttempts: 3}, method: http.MethodPost, want: false},
+		{name: "post with idempotency key", policy: RetryPolicy{MaxAttempts: 3}, method: http.MethodPost, key: "k", want: true},
+		{name: "post when allowed", policy: RetryPolicy{MaxAttempts: 3, RetryNonIdempotent: true}, method: http.MethodPost, want: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req, _ := http.NewRequest(test.method, "http://localhost", nil)
+			if test.key != "" {
+				req.Header.Set("Idempotency-Key", test.key)
+			}
+
+			if allows := test.policy.allows(req); allows != test.want {
+				t.Errorf("allows = %v, want %v", allows, test.want)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyShouldRetry(t *testing.T) {
+	policy := DefaultRetryPolicy()
+
+	tests := []struct {
+		name   string
+		status int
+		err    error
+		want   bool
+	}{
+		{name: "transport error", err: errors.New("connection reset"), want: true},
+		{name: "circuit open", err: ErrCircuitOpen, want: false},
+		{name: "unavailable", status: http.StatusServiceUnavailable, want: true},
+		{name: "too many requests", status: http.StatusTooManyRequests, want: true},
+		{name: "bad request", status: http.StatusBadRequest, want: false},
+		{name: "ok", status: http.StatusOK, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var res *http.Response
+			if test.err == nil {
+				res = &http.Response{StatusCode: test.status}
+			}
+
+			if retry := policy.shouldRetry(res, test.err); retry != test.want {
+				t.Errorf("shouldRetry = %v, want %v", retry, test.want)
+			}
+		})
+	}
+}
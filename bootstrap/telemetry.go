@@ -1,16 +1,35 @@
 package bootstrap
 
 import (
+	"fmt"
+	"net"
 	"os"
+	"strconv"
 
 	"go.opentelemetry.io/contrib/propagators/b3"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
-	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	semconv "go.opentelemetry.io/otel/semconv/v1.25.0"
 	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/credentials"
+)
+
+const (
+	TELEMETRY_EXPORTER_OTLP_GRPC string = "otlp-grpc"
+	TELEMETRY_EXPORTER_OTLP_HTTP string = "otlp-http"
+	TELEMETRY_EXPORTER_STDOUT    string = "stdout"
+	TELEMETRY_EXPORTER_NONE      string = "none"
+
+	TELEMETRY_PROPAGATOR_TRACECONTEXT string = "tracecontext"
+	TELEMETRY_PROPAGATOR_BAGGAGE      string = "baggage"
+	TELEMETRY_PROPAGATOR_B3           string = "b3"
+	TELEMETRY_PROPAGATOR_B3_MULTI     string = "b3multi"
 )
 
 func (c *Container) GetTracer() trace.Tracer {
@@ -18,42 +37,152 @@ func (c *Container) GetTracer() trace.Tracer {
 }
 
 func (c *Container) initTracer() *sdktrace.TracerProvider {
-	if !c.GetConfig().GetBool("telemetry.enable") {
+	// Propagate incoming trace headers even when this service does not export.
+	otel.SetTextMapPropagator(c.textMapPropagator())
+
+	config := c.Config().Telemetry
+	if !config.Enable {
 		c.logrus.Debug("opentelemetry disabled")
 		return nil
 	}
 
 	c.logrus.Debug("opentelemetry initialize")
 
-	host := c.GetConfig().GetString("telemetry.jaeger.agent_host")
-	port := c.GetConfig().GetString("telemetry.jaeger.agent_port")
-
-	c.logrus.
-		WithField("bootstrap", "jaeger").
-		Debugf("trying to connect to %s:%s", host, port)
-
-	exporter, err := otlptracehttp.New(c.ctx, otlptracehttp.WithEndpoint((host + ":" + port)))
+	exporter, err := c.traceExporter()
 	if err != nil {
 		c.logrus.Fatal(err)
 	}
 
-	c.logrus.
-		WithField("bootstrap", "jaeger").
-		Debugf("connected to %s:%s", host, port)
-
-	c.trace = sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter),
-		sdktrace.WithResource(resource.NewWithAttributes(
-			semconv.SchemaURL,
-			semconv.ServiceNameKey.String(os.Getenv("SERVICE_NAME")),
-			attribute.String("environment", os.Getenv("ENV")),
-		)),
-	)
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(config.Jaeger.TraceRatio))),
+		sdktrace.WithResource(c.telemetryResource()),
+	}
+	if exporter != nil {
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
 
+	c.trace = sdktrace.NewTracerProvider(options...)
 	otel.SetTracerProvider(c.trace)
-	otel.SetTextMapPropagator(
-		b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader | b3.B3SingleHeader)),
-	)
 
 	return c.trace
 }
+
+// traceExporter builds the exporter of telemetry.exporter, nil for type none.
+func (c *Container) traceExporter() (sdktrace.SpanExporter, error) {
+	config := c.Config().Telemetry.Exporter
+
+	endpoint := config.Endpoint
+	if endpoint == "" {
+		jaeger := c.Config().Telemetry.Jaeger
+		endpoint = net.JoinHostPort(jaeger.AgentHost, strconv.Itoa(jaeger.AgentPort))
+	}
+
+	logger := c.logrus.WithField("bootstrap", "telemetry")
+
+	switch config.Type {
+	case TELEMETRY_EXPORTER_NONE:
+		logger.Debug("trace exporter disabled")
+		return nil, nil
+	case TELEMETRY_EXPORTER_STDOUT:
+		return stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case TELEMETRY_EXPORTER_OTLP_GRPC:
+		options := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpoint(endpoint),
+			otlptracegrpc.WithHeaders(config.Headers),
+			otlptracegrpc.WithTimeout(config.Timeout),
+		}
+		if config.Compression == "gzip" {
+			options = append(options, otlptracegrpc.WithCompressor("gzip"))
+		}
+		if config.Insecure {
+			options = append(options, otlptracegrpc.WithInsecure())
+		} else {
+			tlsConfig, err := c.tlsConfig(config.Tls, "telemetry.exporter.tls.")
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tlsConfig)))
+		}
+
+		logger.Debugf("exporting traces to %s over grpc", endpoint)
+		c.RegisterHealthCheck("telemetry.otlp", dialHealthCheck(endpoint))
+		return otlptracegrpc.New(c.ctx, options...)
+	case TELEMETRY_EXPORTER_OTLP_HTTP:
+		options := []otlptracehttp.Option{
+			otlptracehttp.WithEndpoint(endpoint),
+			otlptracehttp.WithHeaders(config.Headers),
+			otlptracehttp.WithTimeout(config.Timeout),
+		}
+		if config.Compression == "gzip" {
+			options = append(options, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+		}
+		if config.Insecure {
+			options = append(options, otlptracehttp.WithInsecure())
+		} else {
+			tlsConfig, err := c.tlsConfig(config.Tls, "telemetry.exporter.tls.")
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, otlptracehttp.WithTLSClientConfig(tlsConfig))
+		}
+
+		logger.Debugf("exporting traces to %s over http", endpoint)
+		c.RegisterHealthCheck("telemetry.otlp", dialHealthCheck(endpoint))
+		return otlptracehttp.New(c.ctx, options...)
+	}
+
+	return nil, fmt.Errorf("telemetry.exporter.type: unsupported exporter %q", config.Type)
+}
+
+func (c *Container) textMapPropagator() propagation.TextMapPropagator {
+	propagators := []propagation.TextMapPropagator{}
+	for _, name := range c.Config().Telemetry.Propagators {
+		switch name {
+		case TELEMETRY_PROPAGATOR_TRACECONTEXT:
+			propagators = append(propagators, propagation.TraceContext{})
+		case TELEMETRY_PROPAGATOR_BAGGAGE:
+			propagators = append(propagators, propagation.Baggage{})
+		case TELEMETRY_PROPAGATOR_B3:
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader|b3.B3SingleHeader)))
+		case TELEMETRY_PROPAGATOR_B3_MULTI:
+			propagators = append(propagators, b3.New(b3.WithInjectEncoding(b3.B3MultipleHeader)))
+		}
+	}
+
+	return propagation.NewCompositeTextMapPropagator(propagators...)
+}
+
+func (c *Container) telemetryResource() *resource.Resource {
+	config := c.Config().Telemetry.Resource
+
+	version := config.Version
+	if version == "" {
+		version = os.Getenv("SERVICE_VERSION")
+	}
+
+	attributes := []attribute.KeyValue{
+		semconv.ServiceName(os.Getenv("SERVICE_NAME")),
+		semconv.DeploymentEnvironment(os.Getenv("ENV")),
+		attribute.String("environment", os.Getenv("ENV")),
+	}
+	if version != "" {
+		attributes = append(attributes, semconv.ServiceVersion(version))
+	}
+	for key, value := range config.Attributes {
+		attributes = append(attributes, attribute.String(key, value))
+	}
+
+	res, err := resource.New(c.ctx,
+		resource.WithSchemaURL(semconv.SchemaURL),
+		resource.WithHost(),
+		resource.WithProcessPID(),
+		resource.WithTelemetrySDK(),
+		resource.WithFromEnv(),
+		resource.WithAttributes(attributes...),
+	)
+	if err != nil {
+		c.logrus.Warn("incomplete telemetry resource: ", err)
+	}
+
+	return res
+}
@@ -0,0 +1,115 @@
+package bootstrap
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func telemetryTestContainer(config TelemetryConfig) *Container {
+	c := &Container{ctx: context.Background(), logrus: logrus.NewEntry(logrus.New())}
+	c.config.Store(&AppConfig{Telemetry: config})
+	return c
+}
+
+func TestTextMapPropagator(t *testing.T) {
+	tests := []struct {
+		propagators []string
+		fields      []string
+	}{
+		{propagators: []string{TELEMETRY_PROPAGATOR_TRACECONTEXT}, fields: []string{"traceparent", "tracestate"}},
+		{propagators: []string{TELEMETRY_PROPAGATOR_TRACECONTEXT, TELEMETRY_PROPAGATOR_BAGGAGE}, fields: []string{"baggage", "traceparent", "tracestate"}},
+		{propagators: []string{TELEMETRY_PROPAGATOR_B3}, fields: []string{"b3", "x-b3-flags", "x-b3-sampled", "x-b3-spanid", "x-b3-traceid"}},
+		{propagators: []string{TELEMETRY_PROPAGATOR_B3_MULTI}, fields: []string{"x-b3-flags", "x-b3-sampled", "x-b3-spanid", "x-b3-traceid"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.propagators[len(test.propagators)-1], func(t *testing.T) {
+			c := telemetryTestContainer(TelemetryConfig{Propagators: test.propagators})
+
+			fields := c.textMapPropagator().Fields()
+			sort.Strings(fields)
+			if !reflect.DeepEqual(fields, test.fields) {
+				t.Errorf("fields = %v, want %v", fields, test.fields)
+			}
+		})
+	}
+}
+
+func TestTextMapPropagatorExtract(t *testing.T) {
+	c := telemetryTestContainer(TelemetryConfig{Propagators: []string{TELEMETRY_PROPAGATOR_TRACECONTEXT}})
+
+	header := http.Header{}
+	header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	ctx := c.textMapPropagator().Extract(context.Background(), propagation.HeaderCarrier(header))
+
+	span := trace.SpanContextFromContext(ctx)
+	if span.TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || !span.IsSampled() || !span.IsRemote() {
+		t.Errorf("span = %+v, want the incoming sampled trace", span)
+	}
+}
+
+func TestTraceExporter(t *testing.T) {
+	tests := []struct {
+		exporter string
+		exists   bool
+		fails    bool
+	}{
+		{exporter: TELEMETRY_EXPORTER_NONE},
+		{exporter: TELEMETRY_EXPORTER_STDOUT, exists: true},
+		{exporter: "zipkin", fails: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.exporter, func(t *testing.T) {
+			c := telemetryTestContainer(TelemetryConfig{Exporter: TelemetryExporterConfig{Type: test.exporter}})
+
+			exporter, err := c.traceExporter()
+			if (err != nil) != test.fails {
+				t.Fatalf("error = %v, want failure %v", err, test.fails)
+			}
+			if (exporter != nil) != test.exists {
+				t.Errorf("exporter = %v, want one %v", exporter, test.exists)
+			}
+		})
+	}
+}
+
+func TestInitTracerParentBasedSampling(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	c := telemetryTestContainer(TelemetryConfig{
+		Enable:   true,
+		Exporter: TelemetryExporterConfig{Type: TELEMETRY_EXPORTER_NONE},
+		Jaeger:   JaegerConfig{TraceRatio: 0},
+	})
+	provider := c.initTracer()
+	t.Cleanup(func() { provider.Shutdown(context.Background()) })
+	tracer := provider.Tracer("test")
+
+	_, root := tracer.Start(context.Background(), "root")
+	if root.SpanContext().IsSampled() {
+		t.Errorf("root span sampled, want new traces dropped at ratio 0")
+	}
+
+	traceId, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanId, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	parent := trace.ContextWithRemoteSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceId,
+		SpanID:     spanId,
+		TraceFlags: trace.FlagsSampled,
+		Remote:     true,
+	}))
+	_, child := tracer.Start(parent, "child")
+	if !child.SpanContext().IsSampled() || child.SpanContext().TraceID() != traceId {
+		t.Errorf("child = %+v, want the sampled parent followed", child.SpanContext())
+	}
+}
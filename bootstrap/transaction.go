@@ -0,0 +1,232 @@
+package bootstrap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jmoiron/sqlx"
+)
+
+const TRANSACTION_SAVEPOINT_PREFIX string = "uow_"
+
+// ErrTransactionDriver is returned when a unit of work that already writes
+// through one of sqlx or pgx asks for the other. They use separate connections,
+// so their writes could not commit or roll back together.
+var ErrTransactionDriver = errors.New("unit of work cannot mix sqlx and pgx writes")
+
+type transactionKey struct{}
+
+// PgxExecutor is implemented by both *pgxpool.Pool and pgx.Tx.
+type PgxExecutor interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+	CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error)
+}
+
+// unitOfWork holds the write transaction of one Transaction call, begun on
+// the sqlx or pgx write handle the first repository asks for.
+type unitOfWork struct {
+	mu    sync.Mutex
+	sqlx  *sqlx.Tx
+	pgx   pgx.Tx
+	depth int // Savepoints currently open
+}
+
+// Transaction runs fn in a unit of work, repositories asking for their write
+// handle through Sqlx or Pgx with the ctx given to fn take part in it. The
+// unit commits when fn returns nil and rolls back on an error or a panic.
+//
+// Nested calls run in a savepoint of the outer unit, their error only rolls
+// back what they wrote, and nothing is committed before the outermost call
+// returns. A unit writes through either sqlx or pgx, asking for the other one
+// fails with ErrTransactionDriver.
+func (c *Container) Transaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	unit, nested := ctx.Value(transactionKey{}).(*unitOfWork)
+	if nested {
+		if err := unit.savepoint(ctx); err != nil {
+			return err
+		}
+	} else {
+		unit = &unitOfWork{}
+		ctx = context.WithValue(ctx, transactionKey{}, unit)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			unit.end(ctx, nested, fmt.Errorf("panic: %v", p))
+			panic(p)
+		}
+	}()
+
+	err = fn(ctx)
+	if nested {
+		return unit.release(ctx, err)
+	}
+
+	return c.commit(ctx, unit, err)
+}
+
+// Sqlx returns the transaction of the unit of work in ctx when db is the write
+// database, beginning it on first use, and db otherwise.
+func (c *Container) Sqlx(ctx context.Context, db *sqlx.DB) (sqlx.ExtContext, error) {
+	unit, ok := ctx.Value(transactionKey{}).(*unitOfWork)
+	if !ok || db != c.dbw {
+		return db, nil
+	}
+
+	unit.mu.Lock()
+	defer unit.mu.Unlock()
+
+	if unit.pgx != nil {
+		return nil, ErrTransactionDriver
+	}
+
+	if unit.sqlx == nil {
+		tx, err := db.BeginTxx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		for level := 1; level <= unit.depth; level++ {
+			if _, err := tx.ExecContext(ctx, "SAVEPOINT "+savepointName(level)); err != nil {
+				tx.Rollback()
+				return nil, err
+			}
+		}
+		unit.sqlx = tx
+	}
+
+	return unit.sqlx, nil
+}
+
+// SqlxRead returns db for reads outside a unit of work. Inside one it returns
+// the unit's transaction on the write database instead, so reads see what the
+// unit wrote and the rows it locked rather than a lagging replica.
+func (c *Container) SqlxRead(ctx context.Context, db *sqlx.DB) (sqlx.ExtContext, error) {
+	if _, ok := ctx.Value(transactionKey{}).(*unitOfWork); !ok {
+		return db, nil
+	}
+
+	return c.Sqlx(ctx, c.dbw)
+}
+
+// Pgx returns the transaction of the unit of work in ctx when pool is the
+// write pool, beginning it on first use, and pool otherwise.
+func (c *Container) Pgx(ctx context.Context, pool *pgxpool.Pool) (PgxExecutor, error) {
+	unit, ok := ctx.Value(transactionKey{}).(*unitOfWork)
+	if !ok || pool != c.pgw {
+		return pool, nil
+	}
+
+	unit.mu.Lock()
+	defer unit.mu.Unlock()
+
+	if unit.sqlx != nil {
+		return nil, ErrTransactionDriver
+	}
+
+	if unit.pgx == nil {
+		tx, err := pool.Begin(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for level := 1; level <= unit.depth; level++ {
+			if _, err := tx.Exec(ctx, "SAVEPOINT "+savepointName(level)); err != nil {
+				tx.Rollback(context.WithoutCancel(ctx))
+				return nil, err
+			}
+		}
+		unit.pgx = tx
+	}
+
+	return unit.pgx, nil
+}
+
+func (c *Container) commit(ctx context.Context, unit *unitOfWork, err error) error {
+	if err != nil {
+		unit.end(ctx, false, err)
+		return err
+	}
+
+	unit.mu.Lock()
+	defer unit.mu.Unlock()
+
+	if unit.pgx != nil {
+		return unit.pgx.Commit(ctx)
+	}
+	if unit.sqlx != nil {
+		return unit.sqlx.Commit()
+	}
+
+	return nil
+}
+
+// end rolls back the unit, or only its innermost savepoint when nested.
+func (u *unitOfWork) end(ctx context.Context, nested bool, err error) {
+	if nested {
+		u.release(ctx, err)
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.pgx != nil {
+		u.pgx.Rollback(context.WithoutCancel(ctx))
+	}
+	if u.sqlx != nil {
+		u.sqlx.Rollback()
+	}
+}
+
+func (u *unitOfWork) savepoint(ctx context.Context) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.depth++
+	return u.exec(ctx, "SAVEPOINT "+savepointName(u.depth))
+}
+
+// release closes the innermost savepoint, rolling back to it first when the
+// nested call failed. The error of the nested call wins over its own.
+func (u *unitOfWork) release(ctx context.Context, err error) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	name := savepointName(u.depth)
+	u.depth--
+
+	if err != nil {
+		u.exec(context.WithoutCancel(ctx), "ROLLBACK TO SAVEPOINT "+name)
+		u.exec(context.WithoutCancel(ctx), "RELEASE SAVEPOINT "+name)
+		return err
+	}
+
+	return u.exec(ctx, "RELEASE SAVEPOINT "+name)
+}
+
+func (u *unitOfWork) exec(ctx context.Context, statement string) error {
+	if u.pgx != nil {
+		if _, err := u.pgx.Exec(ctx, statement); err != nil {
+			return err
+		}
+	}
+	if u.sqlx != nil {
+		if _, err := u.sqlx.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func savepointName(level int) string {
+	return TRANSACTION_SAVEPOINT_PREFIX + strconv.Itoa(level)
+}
@@ -0,0 +1,242 @@
+package bootstrap
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+// recordDriver is a database/sql driver that only records the statements run.
+type recordDriver struct {
+	mu         sync.Mutex
+	statements []string
+}
+
+type recordConn struct{ driver *recordDriver }
+
+type recordTx struct{ driver *recordDriver }
+
+func (d *recordDriver) record(statement string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.statements = append(d.statements, statement)
+}
+
+func (d *recordDriver) Connect(context.Context) (driver.Conn, error) { return recordConn{d}, nil }
+func (d *recordDriver) Driver() driver.Driver                        { return nil }
+
+func (c recordConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c recordConn) Close() error                        { return nil }
+func (c recordConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c recordConn) BeginTx(context.Context, driver.TxOptions) (driver.Tx, error) {
+	c.driver.record("BEGIN")
+	return recordTx(c), nil
+}
+
+func (c recordConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	c.driver.record(query)
+	return driver.RowsAffected(1), nil
+}
+
+func (t recordTx) Commit() error   { t.driver.record("COMMIT"); return nil }
+func (t recordTx) Rollback() error { t.driver.record("ROLLBACK"); return nil }
+
+func newTransactionTestContainer(t *testing.T) (*Container, *recordDriver) {
+	t.Helper()
+
+	recorder := &recordDriver{}
+	db := sqlx.NewDb(sql.OpenDB(recorder), "postgres")
+	t.Cleanup(func() { db.Close() })
+
+	return &Container{ctx: context.Background(), logrus: logrus.NewEntry(logrus.New()), dbw: db}, recorder
+}
+
+func transactionTestInsert(c *Container, ctx context.Context, statement string) error {
+	db, err := c.Sqlx(ctx, c.dbw)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, statement)
+	return err
+}
+
+func TestTransaction(t *testing.T) {
+	failure := errors.New("failure")
+
+	tests := []struct {
+		name       string
+		fn         func(c *Container, ctx context.Context) error
+		err        error
+		statements []string
+	}{
+		{
+			name:       "nothing written",
+			fn:         func(c *Container, ctx context.Context) error { return nil },
+			statements: nil,
+		},
+		{
+			name: "commit",
+			fn: func(c *Container, ctx context.Context) error {
+				return transactionTestInsert(c, ctx, "INSERT a")
+			},
+			statements: []string{"BEGIN", "INSERT a", "COMMIT"},
+		},
+		{
+			name: "error rolls back",
+			fn: func(c *Container, ctx context.Context) error {
+				transactionTestInsert(c, ctx, "INSERT a")
+				return failure
+			},
+			err:        failure,
+			statements: []string{"BEGIN", "INSERT a", "ROLLBACK"},
+		},
+		{
+			name: "nested call releases its savepoint",
+			fn: func(c *Container, ctx context.Context) error {
+				transactionTestInsert(c, ctx, "INSERT a")
+				return c.Transaction(ctx, func(ctx context.Context) error {
+					return transactionTestInsert(c, ctx, "INSERT b")
+				})
+			},
+			statements: []string{"BEGIN", "INSERT a", "SAVEPOINT uow_1", "INSERT b", "RELEASE SAVEPOINT uow_1", "COMMIT"},
+		},
+		{
+			name: "nested error only rolls back its savepoint",
+			fn: func(c *Container, ctx context.Context) error {
+				transactionTestInsert(c, ctx, "INSERT a")
+				c.Transaction(ctx, func(ctx context.Context) error {
+					transactionTestInsert(c, ctx, "INSERT b")
+					return failure
+				})
+				return nil
+			},
+			statements: []string{"BEGIN", "INSERT a", "SAVEPOINT uow_1", "INSERT b", "ROLLBACK TO SAVEPOINT uow_1", "RELEASE SAVEPOINT uow_1", "COMMIT"},
+		},
+		{
+			name: "nested error returned rolls back the unit",
+			fn: func(c *Container, ctx context.Context) error {
+				return c.Transaction(ctx, func(ctx context.Context) error {
+					transactionTestInsert(c, ctx, "INSERT a")
+					return failure
+				})
+			},
+			err:        failure,
+			statements: []string{"BEGIN", "SAVEPOINT uow_1", "INSERT a", "ROLLBACK TO SAVEPOINT uow_1", "RELEASE SAVEPOINT uow_1", "ROLLBACK"},
+		},
+		{
+			name: "first write in a nested call opens the savepoints",
+			fn: func(c *Container, ctx context.Context) error {
+				return c.Transaction(ctx, func(ctx context.Context) error {
+					return c.Transaction(ctx, func(ctx context.Context) error {
+						return transactionTestInsert(c, ctx, "INSERT a")
+					})
+				})
+			},
+			statements: []string{"BEGIN", "SAVEPOINT uow_1", "SAVEPOINT uow_2", "INSERT a", "RELEASE SAVEPOINT uow_2", "RELEASE SAVEPOINT uow_1", "COMMIT"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, recorder := newTransactionTestContainer(t)
+
+			err := c.Transaction(context.Background(), func(ctx context.Context) error {
+				return test.fn(c, ctx)
+			})
+			if !errors.Is(err, test.err) {
+				t.Errorf("error = %v, want %v", err, test.err)
+			}
+			if !reflect.DeepEqual(recorder.statements, test.statements) {
+				t.Errorf("statements = %q, want %q", recorder.statements, test.statements)
+			}
+		})
+	}
+}
+
+func TestTransactionPanicRollsBack(t *testing.T) {
+	c, recorder := newTransactionTestContainer(t)
+
+	func() {
+		defer func() {
+			if p := recover(); p != "boom" {
+				t.Errorf("recovered %v, want the panic to be raised again", p)
+			}
+		}()
+
+		c.Transaction(context.Background(), func(ctx context.Context) error {
+			transactionTestInsert(c, ctx, "INSERT a")
+			panic("boom")
+		})
+	}()
+
+	if want := []string{"BEGIN", "INSERT a", "ROLLBACK"}; !reflect.DeepEqual(recorder.statements, want) {
+		t.Errorf("statements = %q, want %q", recorder.statements, want)
+	}
+}
+
+func TestTransactionHandles(t *testing.T) {
+	c, _ := newTransactionTestContainer(t)
+	dbr := sqlx.NewDb(sql.OpenDB(&recordDriver{}), "postgres")
+	defer dbr.Close()
+
+	if db, err := c.Sqlx(context.Background(), c.dbw); err != nil || db != c.dbw {
+		t.Errorf("Sqlx outside a unit = %v, %v, want the write database", db, err)
+	}
+	if db, err := c.SqlxRead(context.Background(), dbr); err != nil || db != dbr {
+		t.Errorf("SqlxRead outside a unit = %v, %v, want the read database", db, err)
+	}
+
+	c.Transaction(context.Background(), func(ctx context.Context) error {
+		if db, err := c.Sqlx(ctx, dbr); err != nil || db != dbr {
+			t.Errorf("Sqlx of the read database = %v, %v, want the read database", db, err)
+		}
+		tx, err := c.Sqlx(ctx, c.dbw)
+		if err != nil || tx == c.dbw {
+			t.Errorf("Sqlx of the write database = %v, %v, want the transaction", tx, err)
+		}
+		if db, err := c.SqlxRead(ctx, dbr); err != nil || db != tx {
+			t.Errorf("SqlxRead of the read database = %v, %v, want the transaction", db, err)
+		}
+		return nil
+	})
+}
+
+func TestTransactionRejectsMixedDrivers(t *testing.T) {
+	c, recorder := newTransactionTestContainer(t)
+
+	// The pool connects lazily, so Pgx fails before it would reach a server
+	pool, err := pgxpool.New(context.Background(), "postgres://localhost:1/test")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer pool.Close()
+	c.pgw = pool
+
+	err = c.Transaction(context.Background(), func(ctx context.Context) error {
+		if err := transactionTestInsert(c, ctx, "INSERT a"); err != nil {
+			return err
+		}
+		_, err := c.Pgx(ctx, c.pgw)
+		return err
+	})
+
+	if !errors.Is(err, ErrTransactionDriver) {
+		t.Errorf("error = %v, want %v", err, ErrTransactionDriver)
+	}
+	if want := []string{"BEGIN", "INSERT a", "ROLLBACK"}; !reflect.DeepEqual(recorder.statements, want) {
+		t.Errorf("statements = %q, want %q", recorder.statements, want)
+	}
+}
@@ -0,0 +1,106 @@
+package bootstrap
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"os"
+)
+
+const HTTP_TRANSPORT_SHARED string = ""
+
+// Transport returns the outbound transport of the client configured under
+// client_configuration.clients.<name>. Clients without their own tls section
+// share one transport so connections are pooled across them.
+func (c *Container) Transport(name string) http.RoundTripper {
+	key := HTTP_TRANSPORT_SHARED
+	config := c.Config().ClientConfiguration
+	tlsConfig := config.Tls
+	prefix := "client_configuration.tls."
+	if client, ok := config.Clients[name]; ok && name != "" && client.Tls != nil {
+		key = name
+		tlsConfig = *client.Tls
+		prefix = "client_configuration.clients." + name + ".tls."
+	}
+
+	if transport, ok := c.transports.Load(key); ok {
+		return transport.(*http.Transport)
+	}
+
+	transport := c.newTransport()
+	clientTls, err := c.tlsConfig(tlsConfig, prefix)
+	if err != nil {
+		c.logrus.Fatal(err)
+	}
+	transport.TLSClientConfig = clientTls
+
+	actual, _ := c.transports.LoadOrStore(key, transport)
+	return actual.(*http.Transport)
+}
+
+func (c *Container) newTransport() *http.Transport {
+	config := c.Config().ClientConfiguration.Transport
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	transport.MaxIdleConns = config.MaxIdleConns
+	transport.MaxIdleConnsPerHost = config.MaxIdleConnsPerHost
+	transport.MaxConnsPerHost = config.MaxConnsPerHost
+	transport.IdleConnTimeout = config.IdleConnTimeout
+	transport.TLSHandshakeTimeout = config.TlsHandshakeTimeout
+
+	return transport
+}
+
+// tlsConfig builds the client TLS settings configured under prefix,
+// certificates are verified against the system pool plus the optional ca_file.
+func (c *Container) tlsConfig(settings TlsConfig, prefix string) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: settings.ServerName,
+	}
+
+	if version := settings.MinVersion; version != "" {
+		switch version {
+		case "1.2":
+			config.MinVersion = tls.VersionTLS12
+		case "1.3":
+			config.MinVersion = tls.VersionTLS13
+		default:
+			return nil, fmt.Errorf("%smin_version: unsupported tls version %q", prefix, version)
+		}
+	}
+
+	if caFile := settings.CaFile; caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, err
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%sca_file: no certificate found in %s", prefix, caFile)
+		}
+		config.RootCAs = pool
+	}
+
+	certFile := settings.CertFile
+	keyFile := settings.KeyFile
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+
+	if settings.InsecureSkipVerify {
+		c.logrus.Warn("tls certificate verification is disabled by ", prefix, "insecure_skip_verify")
+		config.InsecureSkipVerify = true
+	}
+
+	return config, nil
+}
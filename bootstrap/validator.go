@@ -0,0 +1,95 @@
+package bootstrap
+
+import (
+	"regexp"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/id"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	en_translations "github.com/go-playground/validator/v10/translations/en"
+	id_translations "github.com/go-playground/validator/v10/translations/id"
+)
+
+var semanticVersion = regexp.MustCompile(`^v?(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(-[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?(\+[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*)?$`)
+
+// Validator returns the shared struct validator with the translations of every
+// supported locale and the rules registered through RegisterValidation.
+func (c *Container) Validator() *validator.Validate {
+	c.validateOnce.Do(c.initValidator)
+
+	return c.validate
+}
+
+// Translator returns the validation message translator of locale, falling back to english.
+func (c *Container) Translator(locale string) ut.Translator {
+	c.validateOnce.Do(c.initValidator)
+
+	if translator, ok := c.translator.GetTranslator(locale); ok {
+		return translator
+	}
+
+	translator, _ := c.translator.GetTranslator("en")
+	return translator
+}
+
+// RegisterValidation adds a custom rule, messages maps a locale to its text
+// where {0} is the field name and {1} the rule parameter.
+func (c *Container) RegisterValidation(tag string, fn validator.Func, messages map[string]string) error {
+	c.validateOnce.Do(c.initValidator)
+
+	return c.registerValidation(tag, fn, messages)
+}
+
+func (c *Container) initValidator() {
+	english := en.New()
+	c.translator = ut.New(english, english, id.New())
+	c.validate = validator.New(validator.WithRequiredStructEnabled())
+
+	translator, _ := c.translator.GetTranslator("en")
+	if err := en_translations.RegisterDefaultTranslations(c.validate, translator); err != nil {
+		c.logrus.Error(err)
+	}
+
+	translator, _ = c.translator.GetTranslator("id")
+	if err := id_translations.RegisterDefaultTranslations(c.validate, translator); err != nil {
+		c.logrus.Error(err)
+	}
+
+	err := c.registerValidation("version", func(fl validator.FieldLevel) bool {
+		return semanticVersion.MatchString(fl.Field().String())
+	}, map[string]string{
+		"en": "{0} must be a semantic version such as 1.2.3",
+		"id": "{0} harus berupa versi semantik seperti 1.2.3",
+	})
+	if err != nil {
+		c.logrus.Error(err)
+	}
+}
+
+func (c *Container) registerValidation(tag string, fn validator.Func, messages map[string]string) error {
+	err := c.validate.RegisterValidation(tag, fn)
+	if err != nil {
+		return err
+	}
+
+	for locale, message := range messages {
+		translator, ok := c.translator.GetTranslator(locale)
+		if !ok {
+			continue
+		}
+
+		message := message
+		err = c.validate.RegisterTranslation(tag, translator, func(ut ut.Translator) error {
+			return ut.Add(tag, message, true)
+		}, func(ut ut.Translator, fe validator.FieldError) string {
+			text, _ := ut.T(fe.Tag(), fe.Field(), fe.Param())
+			return text
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package bootstrap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/sirupsen/logrus"
+)
+
+type validatorTestModel struct {
+	Version string `validate:"version"`
+}
+
+func TestVersionRule(t *testing.T) {
+	c := &Container{logrus: logrus.NewEntry(logrus.New())}
+
+	tests := []struct {
+		version string
+		valid   bool
+	}{
+		{version: "1.2.3", valid: true},
+		{version: "v1.2.3", valid: true},
+		{version: "0.0.0", valid: true},
+		{version: "10.20.30", valid: true},
+		{version: "1.2.3-rc.1", valid: true},
+		{version: "1.2.3-rc.1+build.5", valid: true},
+		{version: "1.2.3+20240101", valid: true},
+		{version: "", valid: false},
+		{version: "1.2", valid: false},
+		{version: "1.2.3.4", valid: false},
+		{version: "01.2.3", valid: false},
+		{version: "1.2.x", valid: false},
+		{version: "1.2.3-", valid: false},
+		{version: " 1.2.3", valid: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			err := c.Validator().Struct(validatorTestModel{Version: test.version})
+			if valid := err == nil; valid != test.valid {
+				t.Errorf("valid = %v, want %v (%v)", valid, test.valid, err)
+			}
+		})
+	}
+}
+
+func TestVersionRuleMessage(t *testing.T) {
+	c := &Container{logrus: logrus.NewEntry(logrus.New())}
+
+	var validationErrors validator.ValidationErrors
+	if err := c.Validator().Struct(validatorTestModel{Version: "1.2"}); !errors.As(err, &validationErrors) {
+		t.Fatalf("error = %v, want validation errors", err)
+	}
+
+	tests := []struct {
+		locale  string
+		message string
+	}{
+		{locale: "en", message: "Version must be a semantic version such as 1.2.3"},
+		{locale: "id", message: "Version harus berupa versi semantik seperti 1.2.3"},
+		{locale: "fr", message: "Version must be a semantic version such as 1.2.3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.locale, func(t *testing.T) {
+			if message := validationErrors[0].Translate(c.Translator(test.locale)); message != test.message {
+				t.Errorf("message = %q, want %q", message, test.message)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"fmt"
+	"gogin-template/bootstrap"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Configuration",
+	Long:  "Inspect the configuration the other subcommands would run with.",
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the configuration",
+	Long:  "Load the config file, its ENV overlay, environment overrides and secrets, then report every invalid key.",
+	Run: func(cmd *cobra.Command, args []string) {
+		vip, err := bootstrap.ReadConfig()
+		if err == nil {
+			_, err = bootstrap.UnmarshalConfig(vip)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		source := vip.ConfigFileUsed()
+		if source == "" {
+			source = "defaults and environment"
+		}
+		fmt.Printf("config is valid (%s)\n", source)
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+}
@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"gogin-template/bootstrap"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Database Migrations",
+	Long:  "Apply, revert, inspect and create versioned SQL migrations.",
+}
+
+var migrateUpCmd = &cobra.Command{
+	Use:   "up",
+	Short: "Apply pending migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		steps, _ := cmd.Flags().GetInt("steps")
+		Migrate(cmd, func(ctx context.Context, migrator *bootstrap.Migrator) error {
+			return migrator.Up(ctx, steps)
+		})
+	},
+}
+
+var migrateDownCmd = &cobra.Command{
+	Use:   "down",
+	Short: "Revert applied migrations",
+	Run: func(cmd *cobra.Command, args []string) {
+		steps, _ := cmd.Flags().GetInt("steps")
+		Migrate(cmd, func(ctx context.Context, migrator *bootstrap.Migrator) error {
+			return migrator.Down(ctx, steps)
+		})
+	},
+}
+
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show migration status",
+	Run: func(cmd *cobra.Command, args []string) {
+		Migrate(cmd, func(ctx context.Context, migrator *bootstrap.Migrator) error {
+			statuses, err := migrator.Status(ctx)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+			for _, status := range statuses {
+				appliedAt := "pending"
+				if status.AppliedAt != nil {
+					appliedAt = status.AppliedAt.Format(time.RFC3339)
+				}
+				fmt.Fprintf(w, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+			}
+			return w.Flush()
+		})
+	},
+}
+
+var migrateCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a new migration",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		dir, _ := cmd.Flags().GetString("dir")
+		cfg := bootstrap.Init()
+
+		migration, err := bootstrap.NewMigrator(cfg, dir).Create(args[0])
+		if err != nil {
+			cfg.Logger().Fatal(err)
+		}
+
+		cfg.Logger().Infof("created %s and %s", migration.UpPath, migration.DownPath)
+	},
+}
+
+func init() {
+	migrateCmd.PersistentFlags().String("dir", "", "directory containing the migration files")
+	migrateUpCmd.Flags().Int("steps", 0, "number of migrations to apply (0 applies all)")
+	migrateDownCmd.Flags().Int("steps", 1, "number of migrations to revert")
+
+	migrateCmd.AddCommand(
+		migrateUpCmd,
+		migrateDownCmd,
+		migrateStatusCmd,
+		migrateCreateCmd,
+	)
+}
+
+func Migrate(cmd *cobra.Command, fn func(ctx context.Context, migrator *bootstrap.Migrator) error) {
+	cfg := bootstrap.Init()
+	cfg.UpdateLogger(cfg.Logger().WithField("component", "migrate"))
+	defer cfg.Close()
+
+	dir, _ := cmd.Flags().GetString("dir")
+	if err := fn(context.Background(), bootstrap.NewMigrator(cfg, dir)); err != nil {
+		cfg.Logger().Fatal(err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"gogin-template/baselib/middleware"
 	"gogin-template/bootstrap"
 	"gogin-template/internal/controller"
+	"gogin-template/internal/repository"
+	"gogin-template/internal/service"
 	"net/http"
 	"os"
 	"os/signal"
@@ -39,56 +41,75 @@ var restCmd = &cobra.Command{
 // @host      localhost:8080
 // @BasePath  /api/v1
 
+// @securityDefinitions.apikey	BearerAuth
+// @in							header
+// @name						Authorization
+
 func Rest() *cobra.Command {
 	cfg := bootstrap.Init()
 	cfg.UpdateLogger(cfg.Logger().WithField("component", "rest"))
 	cfg.Logger().Info("running rest")
-	config := cfg.GetConfig()
+	config := cfg.Config()
+	cfg.WatchConfig()
 
 	servName := os.Getenv("SERVICE_NAME")
 
 	// Setup Gin-Gonic
 	corsConfig := cors.DefaultConfig()
 	corsConfig.AllowAllOrigins = true
+	corsConfig.ExposeHeaders = config.Server.Correlation.Headers
 
-	ginEngine := gin.Default()
+	ginEngine := gin.New()
 	ginEngine.RedirectTrailingSlash = true
 	ginEngine.RemoveExtraSlash = true
-	ginEngine.Use(gin.Recovery())
-	ginEngine.Use(cors.New(corsConfig))
+	ginEngine.ContextWithFallback = true
 	ginEngine.Use(otelgin.Middleware(servName))
+	ginEngine.Use(middleware.RecoveryMiddleware(cfg))
+	ginEngine.Use(cors.New(corsConfig))
+	ginEngine.Use(middleware.CorrelationMiddleware(cfg))
+	ginEngine.Use(middleware.MetricsMiddleware(cfg))
 	ginEngine.Use(middleware.LoggingMiddleware(cfg))
 	ginEngine.Use(middleware.ExceptionMiddleware(cfg))
 
 	// Create Health
 	controller.NewHealthController(ginEngine, cfg)
 
+	// Route Groups
+	secured := ginEngine.Group("", middleware.AuthMiddleware(cfg, middleware.NewKeySource(cfg)))
+
 	// Get Configs
 
 	// Rest Clients
 
 	// Repositories
+	sampleRepository := repository.NewSampleRepository(cfg.Dbr(), cfg.Dbw(), cfg.Pgr(), cfg.Pgw(), cfg)
+	sampleApprovalRepository := repository.NewSampleApprovalRepository(cfg.Dbr(), cfg.Dbw(), cfg.Pgr(), cfg.Pgw(), cfg)
 
 	// Services
+	sampleService := service.NewSampleService(sampleRepository, sampleApprovalRepository, cfg)
+	sampleApprovalService := service.NewSampleApprovalService(sampleApprovalRepository, sampleRepository, cfg)
 
 	// Controllers
+	controller.NewSampleController(sampleService, secured, cfg)
+	controller.NewSampleApprovalController(sampleApprovalService, secured, cfg)
+	controller.NewLogController(secured, cfg)
+
+	// Define path for Prometheus
+	ginEngine.GET("/metrics", gin.WrapH(cfg.MetricsHandler()))
 
 	// Define path for Swaggo
 	ginEngine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
 	// Start the Server
-	port := config.GetString("server.port")
-	if port == "" {
-		cfg.Logger().Fatal("server port has not been set")
-	}
+	port := config.Server.Port
 
 	server := &http.Server{
 		Handler: ginEngine,
-		Addr:    fmt.Sprintf(":%s", port),
+		Addr:    fmt.Sprintf(":%d", port),
 	}
 
 	go func() {
-		cfg.Logger().Infof("server starting at %s", port)
+		cfg.Logger().Infof("server starting at %d", port)
 		if err := server.ListenAndServe(); err != nil {
 			if err == http.ErrServerClosed {
 				cfg.Logger().Info("server stopped")
@@ -104,15 +125,19 @@ func Rest() *cobra.Command {
 
 	cfg.Logger().Println("shutdown server...")
 
+	// Fail readiness first so load balancers stop sending new requests.
+	cfg.SetShuttingDown()
+	time.Sleep(config.Server.ShutdownDelay)
+
 	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
 	defer cancel()
 
 	cfg.Logger().Info("shutting down the server...")
-	cfg.Close()
-
 	if err := server.Shutdown(ctx); err != nil && err != http.ErrServerClosed {
 		cfg.Logger().Fatal(fmt.Sprintf("failed to gracefully shut down the server %s", err))
 	}
 
+	cfg.Close()
+
 	return nil
 }
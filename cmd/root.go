@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"gogin-template/bootstrap"
 	"os"
 
 	log "github.com/sirupsen/logrus"
@@ -14,9 +15,20 @@ var rootCmd = &cobra.Command{
 	Long:  "Gochi Template",
 }
 
+var configPath string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "config file or directory (default is $CONFIG_PATH or ./config.yaml)")
+	cobra.OnInitialize(func() {
+		bootstrap.SetConfigPath(configPath)
+	})
+}
+
 func Execute() error {
 	rootCmd.AddCommand(
 		restCmd,
+		migrateCmd,
+		configCmd,
 	)
 
 	err := rootCmd.Execute()
@@ -43,106 +43,2017 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Reports that the process is running, dependencies are not checked",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Get Liveness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-bootstrap_HealthReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Checks every registered dependency, responds 503 when any of them is down",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Get Readiness",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-bootstrap_HealthReport"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-bootstrap_HealthReport"
+                        }
+                    }
+                }
+            }
+        },
+        "/log/level": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the current log level of this instance",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Log"
+                ],
+                "summary": "Get Log Level",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-viewmodel_LogLevelRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the log level of this instance, optionally only for a duration",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Log"
+                ],
+                "summary": "Set Log Level",
+                "parameters": [
+                    {
+                        "description": "Log Level",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/viewmodel.LogLevelRqViewModel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-viewmodel_LogLevelRsViewModel"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/sample": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get Samples",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Get Samples",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sample ID",
+                        "name": "sampleId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search Type",
+                        "name": "sampleType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search Query",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page Index",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page Size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort By",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort Direction",
+                        "name": "sortDirection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset Cursor (start for the first page)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter Expression (e.g. sampleType:in:Type_A,Type_B;createDate:gte:2024-01-01)",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-array_viewmodel_SampleRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set Sample Upsert",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Set Sample Upsert",
+                "parameters": [
+                    {
+                        "description": "Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/viewmodel.SampleRqViewModel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-viewmodel_SampleApprovalRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set Sample Insert",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Set Sample Insert",
+                "parameters": [
+                    {
+                        "description": "Sample",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/viewmodel.SampleRqViewModel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-viewmodel_SampleApprovalRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/sample/approvals": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get Sample Approvals",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample Approval"
+                ],
+                "summary": "Get Sample Approvals",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sample ID",
+                        "name": "sampleId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Approval Status",
+                        "name": "approvalStatus",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page Index",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page Size",
+                        "name": "pageSize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort By",
+                        "name": "sortBy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort Direction",
+                        "name": "sortDirection",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Keyset Cursor (start for the first page)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter Expression (e.g. sampleType:in:Type_A,Type_B;createDate:gte:2024-01-01)",
+                        "name": "filter",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-array_viewmodel_SampleApprovalRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/sample/approvals/{approval-id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get Sample Approval",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample Approval"
+                ],
+                "summary": "Get Sample Approval",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Approval ID",
+                        "name": "approval-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-viewmodel_SampleApprovalRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/sample/approvals/{approval-id}/approve": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Approve a pending sample change and apply it",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample Approval"
+                ],
+                "summary": "Set Sample Approval Approve",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Approval ID",
+                        "name": "approval-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sample Approval",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/viewmodel.SampleApprovalRqViewModel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-viewmodel_SampleApprovalRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/sample/approvals/{approval-id}/reject": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Reject a pending sample change",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample Approval"
+                ],
+                "summary": "Set Sample Approval Reject",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Approval ID",
+                        "name": "approval-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sample Approval",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/viewmodel.SampleApprovalRqViewModel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-viewmodel_SampleApprovalRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/sample/version": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set Sample Version Update",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Set Sample Version Update",
+                "parameters": [
+                    {
+                        "description": "Sample Version",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/viewmodel.SampleVersionRqViewModel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set Sample Version Insert",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Set Sample Version Insert",
+                "parameters": [
+                    {
+                        "description": "Sample Version",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/viewmodel.SampleVersionRqViewModel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/sample/versions": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set Sample Versions",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Set Sample Versions",
+                "parameters": [
+                    {
+                        "description": "Sample Version",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/viewmodel.SampleVersionRqViewModel"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/sample/{sample-id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get Sample",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Get Sample",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sample ID",
+                        "name": "sample-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-viewmodel_SampleRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set Sample Delete",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Set Sample Delete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sample ID",
+                        "name": "sample-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Request User ID",
+                        "name": "updateUser",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-viewmodel_SampleApprovalRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/sample/{sample-id}/release": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Insert new versions and activate one of them, all or nothing",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Set Sample Release",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sample ID",
+                        "name": "sample-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sample Release",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/viewmodel.SampleReleaseRqViewModel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-viewmodel_SampleRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/sample/{sample-id}/version": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get Sample Versions",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Get Sample Versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sample ID",
+                        "name": "sample-id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-array_viewmodel_SampleVersionRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/sample/{sample-id}/version/rollback": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore the version that was active before the latest activation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Set Sample Version Rollback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sample ID",
+                        "name": "sample-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sample Version (updateUser)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/viewmodel.SampleVersionRqViewModel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-viewmodel_SampleRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/sample/{sample-id}/version/{version-number}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get Sample Version",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Get Sample Version",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sample ID",
+                        "name": "sample-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sample Version",
+                        "name": "version-number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-viewmodel_SampleVersionRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get Sample Version Delete",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Set Sample Version Delete",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sample ID",
+                        "name": "sample-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sample Version",
+                        "name": "version-number",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
+        },
+        "/sample/{sample-id}/version/{version-number}/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set Sample Version Activate",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sample"
+                ],
+                "summary": "Set Sample Version Activate",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sample ID",
+                        "name": "sample-id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sample Version",
+                        "name": "version-number",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Sample Version (updateUser)",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/viewmodel.SampleVersionRqViewModel"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-viewmodel_SampleRsViewModel"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/dto.Response-any"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
+        "bootstrap.ComponentHealth": {
+            "type": "object",
+            "properties": {
+                "checkedAt": {
+                    "type": "string"
+                },
+                "duration": {
+                    "type": "string",
+                    "example": "1.2ms"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "UP"
+                }
+            }
+        },
+        "bootstrap.HealthReport": {
+            "type": "object",
+            "properties": {
+                "components": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/bootstrap.ComponentHealth"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "UP"
+                }
+            }
+        },
+        "dto.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Field Path",
+                    "type": "string",
+                    "example": "sampleVersions[0].versionNumber"
+                },
+                "message": {
+                    "description": "Translated Message",
+                    "type": "string",
+                    "example": "versionNumber must be a maximum of 10"
+                },
+                "param": {
+                    "description": "Rule Parameter",
+                    "type": "string",
+                    "example": "10"
+                },
+                "rule": {
+                    "description": "Failed Validation Rule",
+                    "type": "string",
+                    "example": "max"
+                }
+            }
+        },
         "dto.PageInfo": {
             "type": "object",
             "properties": {
-                "CurrentPageIndex": {
-                    "description": "Current Page Index",
-                    "type": "integer",
-                    "example": 1
+                "currentPageIndex": {
+                    "description": "Current Page Index",
+                    "type": "integer",
+                    "example": 1
+                },
+                "maxPageIndex": {
+                    "description": "Max Page Index",
+                    "type": "integer",
+                    "example": 10
+                },
+                "nextCursor": {
+                    "description": "Cursor of the Next Page (Keyset mode only)",
+                    "type": "string"
+                },
+                "prevCursor": {
+                    "description": "Cursor of the Previous Page (Keyset mode only)",
+                    "type": "string"
+                },
+                "rowsPerPage": {
+                    "description": "Rows Per Page",
+                    "type": "integer",
+                    "example": 100
+                },
+                "totalAvailableItems": {
+                    "description": "Total Available Items",
+                    "type": "integer",
+                    "example": 1000
+                }
+            }
+        },
+        "dto.Response-any": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data (Any model)"
+                },
+                "errors": {
+                    "description": "Errors (Only for validation failures)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldError"
+                    }
+                },
+                "logReff": {
+                    "description": "LogReff (Use this to search in splunk)",
+                    "type": "string",
+                    "example": "LogReffLogReffLogReffLogReff"
+                },
+                "pageInfo": {
+                    "description": "PageInfo (Only for response type list with pages)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PageInfo"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "description": "Http Response Code",
+                    "type": "string",
+                    "example": "200"
+                },
+                "responseMessage": {
+                    "description": "Response Message",
+                    "type": "string",
+                    "example": "Messages Messages Messages"
+                },
+                "traceId": {
+                    "description": "TraceId (Use this as trace id in jaeger)",
+                    "type": "string",
+                    "example": "TraceIdTraceIdTraceIdTraceId"
+                }
+            }
+        },
+        "dto.Response-array_viewmodel_SampleApprovalRsViewModel": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data (Any model)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/viewmodel.SampleApprovalRsViewModel"
+                    }
+                },
+                "errors": {
+                    "description": "Errors (Only for validation failures)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldError"
+                    }
+                },
+                "logReff": {
+                    "description": "LogReff (Use this to search in splunk)",
+                    "type": "string",
+                    "example": "LogReffLogReffLogReffLogReff"
+                },
+                "pageInfo": {
+                    "description": "PageInfo (Only for response type list with pages)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PageInfo"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "description": "Http Response Code",
+                    "type": "string",
+                    "example": "200"
+                },
+                "responseMessage": {
+                    "description": "Response Message",
+                    "type": "string",
+                    "example": "Messages Messages Messages"
+                },
+                "traceId": {
+                    "description": "TraceId (Use this as trace id in jaeger)",
+                    "type": "string",
+                    "example": "TraceIdTraceIdTraceIdTraceId"
+                }
+            }
+        },
+        "dto.Response-array_viewmodel_SampleRsViewModel": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data (Any model)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/viewmodel.SampleRsViewModel"
+                    }
+                },
+                "errors": {
+                    "description": "Errors (Only for validation failures)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldError"
+                    }
+                },
+                "logReff": {
+                    "description": "LogReff (Use this to search in splunk)",
+                    "type": "string",
+                    "example": "LogReffLogReffLogReffLogReff"
+                },
+                "pageInfo": {
+                    "description": "PageInfo (Only for response type list with pages)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PageInfo"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "description": "Http Response Code",
+                    "type": "string",
+                    "example": "200"
+                },
+                "responseMessage": {
+                    "description": "Response Message",
+                    "type": "string",
+                    "example": "Messages Messages Messages"
+                },
+                "traceId": {
+                    "description": "TraceId (Use this as trace id in jaeger)",
+                    "type": "string",
+                    "example": "TraceIdTraceIdTraceIdTraceId"
+                }
+            }
+        },
+        "dto.Response-array_viewmodel_SampleVersionRsViewModel": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data (Any model)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/viewmodel.SampleVersionRsViewModel"
+                    }
+                },
+                "errors": {
+                    "description": "Errors (Only for validation failures)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldError"
+                    }
+                },
+                "logReff": {
+                    "description": "LogReff (Use this to search in splunk)",
+                    "type": "string",
+                    "example": "LogReffLogReffLogReffLogReff"
+                },
+                "pageInfo": {
+                    "description": "PageInfo (Only for response type list with pages)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PageInfo"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "description": "Http Response Code",
+                    "type": "string",
+                    "example": "200"
+                },
+                "responseMessage": {
+                    "description": "Response Message",
+                    "type": "string",
+                    "example": "Messages Messages Messages"
+                },
+                "traceId": {
+                    "description": "TraceId (Use this as trace id in jaeger)",
+                    "type": "string",
+                    "example": "TraceIdTraceIdTraceIdTraceId"
+                }
+            }
+        },
+        "dto.Response-bootstrap_HealthReport": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data (Any model)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/bootstrap.HealthReport"
+                        }
+                    ]
+                },
+                "errors": {
+                    "description": "Errors (Only for validation failures)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldError"
+                    }
+                },
+                "logReff": {
+                    "description": "LogReff (Use this to search in splunk)",
+                    "type": "string",
+                    "example": "LogReffLogReffLogReffLogReff"
+                },
+                "pageInfo": {
+                    "description": "PageInfo (Only for response type list with pages)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PageInfo"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "description": "Http Response Code",
+                    "type": "string",
+                    "example": "200"
+                },
+                "responseMessage": {
+                    "description": "Response Message",
+                    "type": "string",
+                    "example": "Messages Messages Messages"
+                },
+                "traceId": {
+                    "description": "TraceId (Use this as trace id in jaeger)",
+                    "type": "string",
+                    "example": "TraceIdTraceIdTraceIdTraceId"
+                }
+            }
+        },
+        "dto.Response-string": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data (Any model)",
+                    "type": "string"
+                },
+                "errors": {
+                    "description": "Errors (Only for validation failures)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldError"
+                    }
+                },
+                "logReff": {
+                    "description": "LogReff (Use this to search in splunk)",
+                    "type": "string",
+                    "example": "LogReffLogReffLogReffLogReff"
+                },
+                "pageInfo": {
+                    "description": "PageInfo (Only for response type list with pages)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PageInfo"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "description": "Http Response Code",
+                    "type": "string",
+                    "example": "200"
+                },
+                "responseMessage": {
+                    "description": "Response Message",
+                    "type": "string",
+                    "example": "Messages Messages Messages"
+                },
+                "traceId": {
+                    "description": "TraceId (Use this as trace id in jaeger)",
+                    "type": "string",
+                    "example": "TraceIdTraceIdTraceIdTraceId"
+                }
+            }
+        },
+        "dto.Response-viewmodel_LogLevelRsViewModel": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data (Any model)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/viewmodel.LogLevelRsViewModel"
+                        }
+                    ]
                 },
-                "MaxPageIndex": {
-                    "description": "Max Page Index",
-                    "type": "integer",
-                    "example": 10
+                "errors": {
+                    "description": "Errors (Only for validation failures)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldError"
+                    }
                 },
-                "RowsPerPage": {
-                    "description": "Rows Per Page",
-                    "type": "integer",
-                    "example": 100
+                "logReff": {
+                    "description": "LogReff (Use this to search in splunk)",
+                    "type": "string",
+                    "example": "LogReffLogReffLogReffLogReff"
                 },
-                "TotalAvailableItems": {
-                    "description": "Total Available Items",
-                    "type": "integer",
-                    "example": 1000
+                "pageInfo": {
+                    "description": "PageInfo (Only for response type list with pages)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PageInfo"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "description": "Http Response Code",
+                    "type": "string",
+                    "example": "200"
+                },
+                "responseMessage": {
+                    "description": "Response Message",
+                    "type": "string",
+                    "example": "Messages Messages Messages"
+                },
+                "traceId": {
+                    "description": "TraceId (Use this as trace id in jaeger)",
+                    "type": "string",
+                    "example": "TraceIdTraceIdTraceIdTraceId"
                 }
             }
         },
-        "dto.Response-any": {
+        "dto.Response-viewmodel_SampleApprovalRsViewModel": {
             "type": "object",
             "properties": {
-                "Data": {
-                    "description": "Data (any model)"
+                "data": {
+                    "description": "Data (Any model)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/viewmodel.SampleApprovalRsViewModel"
+                        }
+                    ]
+                },
+                "errors": {
+                    "description": "Errors (Only for validation failures)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldError"
+                    }
                 },
-                "LogReff": {
-                    "description": "LogReff (use this to search in splunk)",
+                "logReff": {
+                    "description": "LogReff (Use this to search in splunk)",
                     "type": "string",
                     "example": "LogReffLogReffLogReffLogReff"
                 },
-                "PageInfo": {
-                    "description": "PageInfo (only for response type list with pages)",
+                "pageInfo": {
+                    "description": "PageInfo (Only for response type list with pages)",
                     "allOf": [
                         {
                             "$ref": "#/definitions/dto.PageInfo"
                         }
                     ]
                 },
-                "ResponseCode": {
+                "responseCode": {
                     "description": "Http Response Code",
                     "type": "string",
                     "example": "200"
                 },
-                "ResponseMessage": {
-                    "description": "Response Message)",
+                "responseMessage": {
+                    "description": "Response Message",
                     "type": "string",
                     "example": "Messages Messages Messages"
                 },
-                "TraceId": {
-                    "description": "TraceId (use this as trace id in jaeger)",
+                "traceId": {
+                    "description": "TraceId (Use this as trace id in jaeger)",
                     "type": "string",
                     "example": "TraceIdTraceIdTraceIdTraceId"
                 }
             }
         },
-        "dto.Response-string": {
+        "dto.Response-viewmodel_SampleRsViewModel": {
             "type": "object",
             "properties": {
-                "Data": {
-                    "description": "Data (any model)",
-                    "type": "string"
+                "data": {
+                    "description": "Data (Any model)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/viewmodel.SampleRsViewModel"
+                        }
+                    ]
+                },
+                "errors": {
+                    "description": "Errors (Only for validation failures)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldError"
+                    }
+                },
+                "logReff": {
+                    "description": "LogReff (Use this to search in splunk)",
+                    "type": "string",
+                    "example": "LogReffLogReffLogReffLogReff"
                 },
-                "LogReff": {
-                    "description": "LogReff (use this to search in splunk)",
+                "pageInfo": {
+                    "description": "PageInfo (Only for response type list with pages)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.PageInfo"
+                        }
+                    ]
+                },
+                "responseCode": {
+                    "description": "Http Response Code",
+                    "type": "string",
+                    "example": "200"
+                },
+                "responseMessage": {
+                    "description": "Response Message",
+                    "type": "string",
+                    "example": "Messages Messages Messages"
+                },
+                "traceId": {
+                    "description": "TraceId (Use this as trace id in jaeger)",
+                    "type": "string",
+                    "example": "TraceIdTraceIdTraceIdTraceId"
+                }
+            }
+        },
+        "dto.Response-viewmodel_SampleVersionRsViewModel": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Data (Any model)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/viewmodel.SampleVersionRsViewModel"
+                        }
+                    ]
+                },
+                "errors": {
+                    "description": "Errors (Only for validation failures)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.FieldError"
+                    }
+                },
+                "logReff": {
+                    "description": "LogReff (Use this to search in splunk)",
                     "type": "string",
                     "example": "LogReffLogReffLogReffLogReff"
                 },
-                "PageInfo": {
-                    "description": "PageInfo (only for response type list with pages)",
+                "pageInfo": {
+                    "description": "PageInfo (Only for response type list with pages)",
                     "allOf": [
                         {
                             "$ref": "#/definitions/dto.PageInfo"
                         }
                     ]
                 },
-                "ResponseCode": {
+                "responseCode": {
                     "description": "Http Response Code",
                     "type": "string",
                     "example": "200"
                 },
-                "ResponseMessage": {
-                    "description": "Response Message)",
+                "responseMessage": {
+                    "description": "Response Message",
                     "type": "string",
                     "example": "Messages Messages Messages"
                 },
-                "TraceId": {
-                    "description": "TraceId (use this as trace id in jaeger)",
+                "traceId": {
+                    "description": "TraceId (Use this as trace id in jaeger)",
                     "type": "string",
                     "example": "TraceIdTraceIdTraceIdTraceId"
                 }
             }
+        },
+        "viewmodel.LogLevelRqViewModel": {
+            "description": "Log Level Change Request",
+            "type": "object",
+            "required": [
+                "level"
+            ],
+            "properties": {
+                "duration": {
+                    "description": "How long the level applies before reverting to log.level, empty keeps it",
+                    "type": "string",
+                    "example": "15m"
+                },
+                "level": {
+                    "description": "Log level to switch to",
+                    "type": "string",
+                    "enum": [
+                        "trace",
+                        "debug",
+                        "info",
+                        "warn",
+                        "error"
+                    ],
+                    "example": "debug"
+                }
+            }
+        },
+        "viewmodel.LogLevelRsViewModel": {
+            "description": "Log Level Response",
+            "type": "object",
+            "properties": {
+                "configuredLevel": {
+                    "description": "Level from log.level",
+                    "type": "string",
+                    "example": "info"
+                },
+                "expiresAt": {
+                    "description": "When the current level reverts to log.level",
+                    "type": "string",
+                    "example": "2001-01-01 01:01:01"
+                },
+                "level": {
+                    "description": "Current log level",
+                    "type": "string",
+                    "example": "debug"
+                }
+            }
+        },
+        "viewmodel.SampleApprovalRqViewModel": {
+            "description": "Sample Approval Request",
+            "type": "object",
+            "properties": {
+                "approvalId": {
+                    "description": "Identification for Approval",
+                    "type": "string",
+                    "example": "0b5e4f2c-7f4e-4b8e-9d0a-2f1d1c3b4a5e"
+                },
+                "approvalReason": {
+                    "description": "Reason for the Decision (freetext)",
+                    "type": "string",
+                    "example": "Reason Reason Reason"
+                },
+                "approvalStatus": {
+                    "description": "Status of Approval (PENDING,APPROVED,REJECTED)",
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "APPROVED",
+                        "REJECTED"
+                    ],
+                    "example": "PENDING"
+                },
+                "approvalUser": {
+                    "description": "Approver (Checker) User ID",
+                    "type": "string",
+                    "example": "22222"
+                },
+                "sampleId": {
+                    "description": "Identification for Sample",
+                    "type": "string",
+                    "example": "SampleId00001"
+                }
+            }
+        },
+        "viewmodel.SampleApprovalRsViewModel": {
+            "description": "Sample Approval Response",
+            "type": "object",
+            "properties": {
+                "approvalAction": {
+                    "description": "Staged Action (Insert,Update,Delete)",
+                    "type": "string",
+                    "enum": [
+                        "Insert",
+                        "Update",
+                        "Delete"
+                    ],
+                    "example": "Insert"
+                },
+                "approvalDate": {
+                    "description": "Decision Date \u0026 Time",
+                    "type": "string",
+                    "example": "2002-02-02 02:02:02"
+                },
+                "approvalId": {
+                    "description": "Identification for Approval",
+                    "type": "string",
+                    "example": "0b5e4f2c-7f4e-4b8e-9d0a-2f1d1c3b4a5e"
+                },
+                "approvalReason": {
+                    "description": "Reason for the Decision (freetext)",
+                    "type": "string",
+                    "example": "Reason Reason Reason"
+                },
+                "approvalStatus": {
+                    "description": "Status of Approval (PENDING,APPROVED,REJECTED)",
+                    "type": "string",
+                    "enum": [
+                        "PENDING",
+                        "APPROVED",
+                        "REJECTED"
+                    ],
+                    "example": "PENDING"
+                },
+                "approvalUser": {
+                    "description": "Approver (Checker) User ID",
+                    "type": "string",
+                    "example": "22222"
+                },
+                "requestDate": {
+                    "description": "Requested Date \u0026 Time",
+                    "type": "string",
+                    "example": "2001-01-01 01:01:01"
+                },
+                "requestUser": {
+                    "description": "Requester (Maker) User ID",
+                    "type": "string",
+                    "example": "11111"
+                },
+                "sample": {
+                    "description": "Staged Sample Data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/viewmodel.SampleRsViewModel"
+                        }
+                    ]
+                },
+                "sampleId": {
+                    "description": "Identification for Sample",
+                    "type": "string",
+                    "example": "SampleId00001"
+                }
+            }
+        },
+        "viewmodel.SampleReleaseRqViewModel": {
+            "description": "Sample Versions to Insert and the one of them to Activate",
+            "type": "object",
+            "properties": {
+                "activeVersion": {
+                    "description": "Version to Activate, one of Versions",
+                    "type": "string",
+                    "example": "1.23.32"
+                },
+                "sampleId": {
+                    "description": "Idetification for Sample",
+                    "type": "string",
+                    "example": "SampleId00001"
+                },
+                "updateUser": {
+                    "description": "Last Updated User ID",
+                    "type": "string",
+                    "example": "33333"
+                },
+                "versions": {
+                    "description": "Versions to Insert",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/viewmodel.SampleVersionRqViewModel"
+                    }
+                }
+            }
+        },
+        "viewmodel.SampleRqViewModel": {
+            "description": "Sample Data Request",
+            "type": "object",
+            "properties": {
+                "createApprover": {
+                    "description": "Created Approver ID",
+                    "type": "string",
+                    "example": "22222"
+                },
+                "createDate": {
+                    "description": "Created Date \u0026 Time",
+                    "type": "string",
+                    "example": "2001-01-01 01:01:01"
+                },
+                "createUser": {
+                    "description": "Created User ID",
+                    "type": "string",
+                    "example": "11111"
+                },
+                "sampleActiveVersion": {
+                    "description": "Current Active Version of Sample",
+                    "type": "string",
+                    "example": "1.23.32"
+                },
+                "sampleDescription": {
+                    "description": "Description of Sample (freetext)",
+                    "type": "string",
+                    "example": "Description Description Description"
+                },
+                "sampleId": {
+                    "description": "Idetification for Sample",
+                    "type": "string",
+                    "example": "SampleId00001"
+                },
+                "sampleName": {
+                    "description": "Name of Sample (freetext)",
+                    "type": "string",
+                    "example": "Sample Name 1"
+                },
+                "sampleType": {
+                    "description": "Type of Sample (Type_A,Type_B,Type_C)",
+                    "type": "string",
+                    "enum": [
+                        "Type_A",
+                        "Type_B",
+                        "Type_C"
+                    ],
+                    "example": "Type_A"
+                },
+                "updateApprover": {
+                    "type": "string",
+                    "example": "44444"
+                },
+                "updateDate": {
+                    "description": "Last Updated Date \u0026 Time",
+                    "type": "string",
+                    "example": "2002-02-02 02:02:02"
+                },
+                "updateUser": {
+                    "description": "Last Updated User ID",
+                    "type": "string",
+                    "example": "33333"
+                }
+            }
+        },
+        "viewmodel.SampleRsViewModel": {
+            "description": "Sample Data Response",
+            "type": "object",
+            "properties": {
+                "createApprover": {
+                    "description": "Created Approver ID",
+                    "type": "string",
+                    "example": "22222"
+                },
+                "createDate": {
+                    "description": "Created Date \u0026 Time",
+                    "type": "string",
+                    "example": "2001-01-01 01:01:01"
+                },
+                "createUser": {
+                    "description": "Created User ID",
+                    "type": "string",
+                    "example": "11111"
+                },
+                "sampleActiveVersion": {
+                    "description": "Current Active Version of Sample",
+                    "type": "string",
+                    "example": "1.23.32"
+                },
+                "sampleDescription": {
+                    "description": "Description of Sample (freetext)",
+                    "type": "string",
+                    "example": "Description Description Description"
+                },
+                "sampleId": {
+                    "description": "Idetification for Sample",
+                    "type": "string",
+                    "example": "SampleId00001"
+                },
+                "sampleName": {
+                    "description": "Name of Sample (freetext)",
+                    "type": "string",
+                    "example": "Sample Name 1"
+                },
+                "sampleType": {
+                    "description": "Type of Sample (Type_A,Type_B,Type_C)",
+                    "type": "string",
+                    "enum": [
+                        "Type_A",
+                        "Type_B",
+                        "Type_C"
+                    ],
+                    "example": "Type_A"
+                },
+                "sampleVersions": {
+                    "description": "List of All Sample Version",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/viewmodel.SampleVersionRsViewModel"
+                    }
+                },
+                "updateApprover": {
+                    "description": "Last Updated Approver ID",
+                    "type": "string",
+                    "example": "44444"
+                },
+                "updateDate": {
+                    "description": "Last Updated Date \u0026 Time",
+                    "type": "string",
+                    "example": "2002-02-02 02:02:02"
+                },
+                "updateUser": {
+                    "description": "Last Updated User ID",
+                    "type": "string",
+                    "example": "33333"
+                }
+            }
+        },
+        "viewmodel.SampleVersionRqViewModel": {
+            "description": "Sample Version Data Request",
+            "type": "object",
+            "properties": {
+                "createApprover": {
+                    "description": "Created Approver ID",
+                    "type": "string",
+                    "example": "22222"
+                },
+                "createDate": {
+                    "description": "Created Date \u0026 Time",
+                    "type": "string",
+                    "example": "2001-01-01 01:01:01"
+                },
+                "createUser": {
+                    "description": "Created User ID",
+                    "type": "string",
+                    "example": "11111"
+                },
+                "sampleId": {
+                    "description": "Idetification for Sample",
+                    "type": "string",
+                    "example": "SampleId00001"
+                },
+                "updateApprover": {
+                    "description": "Last Updated Approver ID",
+                    "type": "string",
+                    "example": "44444"
+                },
+                "updateDate": {
+                    "description": "Last Updated Date \u0026 Time",
+                    "type": "string",
+                    "example": "2002-02-02 02:02:02"
+                },
+                "updateUser": {
+                    "description": "Last Updated User ID",
+                    "type": "string",
+                    "example": "33333"
+                },
+                "versionNumber": {
+                    "description": "Version of Sample",
+                    "type": "string",
+                    "example": "1.23.32"
+                }
+            }
+        },
+        "viewmodel.SampleVersionRsViewModel": {
+            "description": "Sample Version Data Respponse",
+            "type": "object",
+            "properties": {
+                "createApprover": {
+                    "description": "Created Approver ID",
+                    "type": "string",
+                    "example": "22222"
+                },
+                "createDate": {
+                    "description": "Created Date \u0026 Time",
+                    "type": "string",
+                    "example": "2001-01-01 01:01:01"
+                },
+                "createUser": {
+                    "description": "Created User ID",
+                    "type": "string",
+                    "example": "11111"
+                },
+                "sampleId": {
+                    "description": "Idetification for Sample",
+                    "type": "string",
+                    "example": "SampleId00001"
+                },
+                "updateApprover": {
+                    "description": "Last Updated Approver ID",
+                    "type": "string",
+                    "example": "44444"
+                },
+                "updateDate": {
+                    "description": "Last Updated Date \u0026 Time",
+                    "type": "string",
+                    "example": "2002-02-02 02:02:02"
+                },
+                "updateUser": {
+                    "description": "Last Updated User ID",
+                    "type": "string",
+                    "example": "33333"
+                },
+                "versionNumber": {
+                    "description": "Version of Sample",
+                    "type": "string",
+                    "example": "1.23.32"
+                }
+            }
         }
     }
 }`
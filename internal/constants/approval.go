@@ -0,0 +1,13 @@
+package constants
+
+const (
+	APPROVAL_STATUS_PENDING  string = "PENDING"
+	APPROVAL_STATUS_APPROVED string = "APPROVED"
+	APPROVAL_STATUS_REJECTED string = "REJECTED"
+)
+
+const (
+	APPROVAL_ACTION_INSERT string = "Insert"
+	APPROVAL_ACTION_UPDATE string = "Update"
+	APPROVAL_ACTION_DELETE string = "Delete"
+)
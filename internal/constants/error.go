@@ -0,0 +1,25 @@
+package constants
+
+import (
+	"gogin-template/baselib/exception"
+	"net/http"
+)
+
+// Business error codes of the sample domain, translations live in i18n/<locale>.yaml.
+var (
+	ERROR_SAMPLE_NOT_FOUND                = exception.Register("SMP-0001", http.StatusNotFound, "Sample {sampleId} not found")
+	ERROR_SAMPLE_ID_REQUIRED              = exception.Register("SMP-0002", http.StatusBadRequest, "Sample ID is required")
+	ERROR_REQUEST_USER_REQUIRED           = exception.Register("SMP-0003", http.StatusBadRequest, "Request user is required")
+	ERROR_SAMPLE_ACTIVE_VERSION_READONLY  = exception.Register("SMP-0004", http.StatusBadRequest, "Sample active version can only be changed through activate or rollback")
+	ERROR_SAMPLE_PENDING_CHANGE           = exception.Register("SMP-0005", http.StatusBadRequest, "Sample already has a pending change ({approvalId})")
+	ERROR_SAMPLE_ACTIVE_VERSION_DELETE    = exception.Register("SMP-0006", http.StatusBadRequest, "Cannot delete the active version of the sample")
+	ERROR_SAMPLE_VERSION_ALREADY_ACTIVE   = exception.Register("SMP-0007", http.StatusBadRequest, "Version {versionNumber} is already active")
+	ERROR_SAMPLE_VERSION_NOT_FOUND        = exception.Register("SMP-0008", http.StatusNotFound, "Version {versionNumber} not found")
+	ERROR_SAMPLE_ACTIVE_VERSION_CONFLICT  = exception.Register("SMP-0009", http.StatusConflict, "Active version was changed by another request, please retry")
+	ERROR_SAMPLE_NO_ROLLBACK_VERSION      = exception.Register("SMP-0010", http.StatusBadRequest, "There is no previous version to roll back to")
+	ERROR_SAMPLE_ALREADY_EXISTS           = exception.Register("SMP-0011", http.StatusConflict, "Sample {sampleId} already exists")
+	ERROR_APPROVAL_NOT_FOUND              = exception.Register("SMP-0101", http.StatusNotFound, "Approval {approvalId} not found")
+	ERROR_APPROVAL_ALREADY_DECIDED        = exception.Register("SMP-0102", http.StatusBadRequest, "Approval {approvalId} has already been decided")
+	ERROR_APPROVAL_USER_REQUIRED          = exception.Register("SMP-0103", http.StatusBadRequest, "Approval user is required")
+	ERROR_APPROVAL_USER_SAME_AS_REQUESTER = exception.Register("SMP-0104", http.StatusBadRequest, "Approval user must be different from the request user")
+)
@@ -0,0 +1,11 @@
+package constants
+
+const (
+	PERMISSION_SAMPLE_READ    string = "sample:read"
+	PERMISSION_SAMPLE_WRITE   string = "sample:write"
+	PERMISSION_SAMPLE_DELETE  string = "sample:delete"
+	PERMISSION_SAMPLE_VERSION string = "sample:version"
+	PERMISSION_SAMPLE_APPROVE string = "sample:approve"
+
+	PERMISSION_LOG_LEVEL string = "log:level"
+)
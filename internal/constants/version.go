@@ -0,0 +1,6 @@
+package constants
+
+const (
+	VERSION_ACTION_ACTIVATE string = "ACTIVATE"
+	VERSION_ACTION_ROLLBACK string = "ROLLBACK"
+)
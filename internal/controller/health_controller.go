@@ -22,6 +22,8 @@ func NewHealthController(server *gin.Engine, cfg *bootstrap.Container) {
 	route := server.Group("/health")
 	{
 		route.GET("", controller.GetHealth)
+		route.GET("/live", controller.GetLiveness)
+		route.GET("/ready", controller.GetReadiness)
 	}
 }
 
@@ -43,3 +45,49 @@ func (c *HealthController) GetHealth(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, resp)
 }
+
+// @Summary      Get Liveness
+// @Description  Reports that the process is running, dependencies are not checked
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  dto.Response[bootstrap.HealthReport]
+// @Router       /health/live [get]
+func (c *HealthController) GetLiveness(ctx *gin.Context) {
+	resp := &dto.Response[bootstrap.HealthReport]{
+		ResponseCode:    strconv.Itoa(http.StatusOK),
+		ResponseMessage: "Success",
+		LogReff:         identifier.GetLogReff(ctx),
+		TraceId:         identifier.GetTraceId(ctx),
+		Data:            bootstrap.HealthReport{Status: bootstrap.HEALTH_STATUS_UP},
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// @Summary      Get Readiness
+// @Description  Checks every registered dependency, responds 503 when any of them is down
+// @Tags         Health
+// @Produce      json
+// @Success      200  {object}  dto.Response[bootstrap.HealthReport]
+// @Failure      503  {object}  dto.Response[bootstrap.HealthReport]
+// @Router       /health/ready [get]
+func (c *HealthController) GetReadiness(ctx *gin.Context) {
+	report := c.cfg.CheckHealth(ctx)
+
+	status := http.StatusOK
+	message := "Success"
+	if report.Status != bootstrap.HEALTH_STATUS_UP {
+		status = http.StatusServiceUnavailable
+		message = "Service Unavailable"
+	}
+
+	resp := &dto.Response[bootstrap.HealthReport]{
+		ResponseCode:    strconv.Itoa(status),
+		ResponseMessage: message,
+		LogReff:         identifier.GetLogReff(ctx),
+		TraceId:         identifier.GetTraceId(ctx),
+		Data:            report,
+	}
+
+	ctx.JSON(status, resp)
+}
@@ -0,0 +1,123 @@
+package controller
+
+import (
+	"gogin-template/baselib/dto"
+	"gogin-template/baselib/exception"
+	"gogin-template/baselib/helper"
+	"gogin-template/baselib/identifier"
+	"gogin-template/baselib/middleware"
+	"gogin-template/bootstrap"
+	"gogin-template/internal/constants"
+	"gogin-template/internal/viewmodel"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+type LogController struct {
+	cfg *bootstrap.Container
+}
+
+func NewLogController(server gin.IRouter, cfg *bootstrap.Container) {
+	controller := &LogController{
+		cfg: cfg,
+	}
+
+	level := middleware.RbacMiddleware(cfg, constants.PERMISSION_LOG_LEVEL)
+
+	routes := server.Group("/log")
+	{
+		routes.GET("/level", level, controller.GetLogLevel)
+		routes.PUT("/level", level, controller.SetLogLevel)
+	}
+}
+
+// @Summary 	Get Log Level
+// @Description Get the current log level of this instance
+// @Tags 		Log
+// @Produce  	json
+// @Success 	200	{object} 	dto.Response[viewmodel.LogLevelRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
+// @Router 		/log/level 	[get]
+func (c *LogController) GetLogLevel(ctx *gin.Context) {
+	resp := &dto.Response[viewmodel.LogLevelRsViewModel]{
+		ResponseCode:    strconv.Itoa(http.StatusOK),
+		ResponseMessage: "Success",
+		LogReff:         identifier.GetLogReff(ctx),
+		TraceId:         identifier.GetTraceId(ctx),
+		Data:            c.logLevel(),
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// @Summary 	Set Log Level
+// @Description Change the log level of this instance, optionally only for a duration
+// @Tags 		Log
+// @Accept 		json
+// @Produce  	json
+// @Param 		request	body	viewmodel.LogLevelRqViewModel	true	"Log Level"
+// @Success 	200	{object} 	dto.Response[viewmodel.LogLevelRsViewModel]
+// @Failure 	400	{object} 	dto.Response[any]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
+// @Router 		/log/level 	[put]
+func (c *LogController) SetLogLevel(ctx *gin.Context) {
+	var request viewmodel.LogLevelRqViewModel
+	err := ctx.ShouldBindJSON(&request)
+	if err != nil {
+		ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
+		return
+	}
+
+	err = helper.ValidateStruct(ctx, c.cfg, &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	var duration time.Duration
+	if request.Duration != "" {
+		duration, err = time.ParseDuration(request.Duration)
+		if err != nil {
+			ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
+			return
+		}
+	}
+
+	level, err := logrus.ParseLevel(request.Level)
+	if err != nil {
+		ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
+		return
+	}
+
+	c.cfg.SetLogLevel(level, duration)
+	c.cfg.Log(ctx).WithFields(logrus.Fields{
+		"logLevel": level.String(),
+		"duration": request.Duration,
+	}).Warn("log level changed")
+
+	resp := &dto.Response[viewmodel.LogLevelRsViewModel]{
+		ResponseCode:    strconv.Itoa(http.StatusOK),
+		ResponseMessage: "Success",
+		LogReff:         identifier.GetLogReff(ctx),
+		TraceId:         identifier.GetTraceId(ctx),
+		Data:            c.logLevel(),
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *LogController) logLevel() viewmodel.LogLevelRsViewModel {
+	level, expiresAt := c.cfg.LogLevel()
+
+	return viewmodel.LogLevelRsViewModel{
+		Level:           level.String(),
+		ConfiguredLevel: c.cfg.Config().Log.Level,
+		ExpiresAt:       expiresAt,
+	}
+}
@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"gogin-template/baselib/dto"
+	"gogin-template/baselib/exception"
+	"gogin-template/baselib/identifier"
+	"gogin-template/baselib/middleware"
+	"gogin-template/bootstrap"
+	"gogin-template/internal/constants"
+	"gogin-template/internal/service"
+	"gogin-template/internal/viewmodel"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SampleApprovalController struct {
+	service service.SampleApprovalService
+	cfg     *bootstrap.Container
+}
+
+func NewSampleApprovalController(service service.SampleApprovalService, server gin.IRouter, cfg *bootstrap.Container) {
+	controller := &SampleApprovalController{
+		service: service,
+		cfg:     cfg,
+	}
+
+	read := middleware.RbacMiddleware(cfg, constants.PERMISSION_SAMPLE_READ)
+	approve := middleware.RbacMiddleware(cfg, constants.PERMISSION_SAMPLE_APPROVE)
+
+	routes := server.Group("/sample/approvals")
+	{
+		routes.GET("", read, controller.GetSampleApprovals)
+		routes.GET("/:approval-id", read, controller.GetSampleApproval)
+
+		routes.POST("/:approval-id/approve", approve, controller.SetSampleApprovalApprove)
+		routes.POST("/:approval-id/reject", approve, controller.SetSampleApprovalReject)
+	}
+}
+
+// @Summary 	Get Sample Approvals
+// @Description Get Sample Approvals
+// @Tags 		Sample Approval
+// @Produce  	json
+// @Param       sampleId		query  	string  false	"Sample ID"
+// @Param       approvalStatus	query  	string  false	"Approval Status"
+// @Param       page			query	int		false	"Page Index"
+// @Param       pageSize		query	int		false	"Page Size"
+// @Param       sortBy			query	string	false	"Sort By"
+// @Param       sortDirection	query	string	false	"Sort Direction"
+// @Param       cursor			query	string	false	"Keyset Cursor (start for the first page)"
+// @Param       filter			query	string	false	"Filter Expression (e.g. sampleType:in:Type_A,Type_B;createDate:gte:2024-01-01)"
+// @Success 	200	{object} 	dto.Response[[]viewmodel.SampleApprovalRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
+// @Router 		/sample/approvals 	[get]
+func (c *SampleApprovalController) GetSampleApprovals(ctx *gin.Context) {
+	var request viewmodel.SampleApprovalRqViewModel
+	err := ctx.ShouldBindQuery(&request)
+	if err != nil {
+		ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
+		return
+	}
+
+	pagination := dto.PageRequest{}
+	err = ctx.ShouldBindQuery(&pagination)
+	if err != nil {
+		ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
+		return
+	}
+
+	response, pageInfo, err := c.service.GetSampleApprovals(ctx.Request.Context(), &request, pagination)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	resp := &dto.Response[*[]viewmodel.SampleApprovalRsViewModel]{
+		ResponseCode:    strconv.Itoa(http.StatusOK),
+		ResponseMessage: "Success",
+		LogReff:         identifier.GetLogReff(ctx),
+		TraceId:         identifier.GetTraceId(ctx),
+		Data:            response,
+		PageInfo:        pageInfo,
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// @Summary 	Get Sample Approval
+// @Description Get Sample Approval
+// @Tags 		Sample Approval
+// @Produce  	json
+// @Param       approval-id		path  	string  true	"Approval ID"
+// @Success 	200	{object} 	dto.Response[viewmodel.SampleApprovalRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
+// @Router 		/sample/approvals/{approval-id} 	[get]
+func (c *SampleApprovalController) GetSampleApproval(ctx *gin.Context) {
+	approvalId := ctx.Param("approval-id")
+
+	request := &viewmodel.SampleApprovalRqViewModel{ApprovalId: approvalId}
+
+	response, err := c.service.GetSampleApproval(ctx, request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	resp := &dto.Response[*viewmodel.SampleApprovalRsViewModel]{
+		ResponseCode:    strconv.Itoa(http.StatusOK),
+		ResponseMessage: "Success",
+		LogReff:         identifier.GetLogReff(ctx),
+		TraceId:         identifier.GetTraceId(ctx),
+		Data:            response,
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// @Summary 	Set Sample Approval Approve
+// @Description Approve a pending sample change and apply it
+// @Tags 		Sample Approval
+// @Accept  	json
+// @Produce  	json
+// @Param       approval-id		path  	string  true	"Approval ID"
+// @Param       request			body 	viewmodel.SampleApprovalRqViewModel  false  "Sample Approval"
+// @Success 	200	{object} 	dto.Response[viewmodel.SampleApprovalRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
+// @Router 		/sample/approvals/{approval-id}/approve [post]
+func (c *SampleApprovalController) SetSampleApprovalApprove(ctx *gin.Context) {
+	c.setSampleApproval(ctx, "Approve")
+}
+
+// @Summary 	Set Sample Approval Reject
+// @Description Reject a pending sample change
+// @Tags 		Sample Approval
+// @Accept  	json
+// @Produce  	json
+// @Param       approval-id		path  	string  true	"Approval ID"
+// @Param       request			body 	viewmodel.SampleApprovalRqViewModel  false  "Sample Approval"
+// @Success 	200	{object} 	dto.Response[viewmodel.SampleApprovalRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
+// @Router 		/sample/approvals/{approval-id}/reject [post]
+func (c *SampleApprovalController) SetSampleApprovalReject(ctx *gin.Context) {
+	c.setSampleApproval(ctx, "Reject")
+}
+
+func (c *SampleApprovalController) setSampleApproval(ctx *gin.Context, action string) {
+	var request viewmodel.SampleApprovalRqViewModel
+	if ctx.Request.ContentLength != 0 {
+		err := ctx.ShouldBindJSON(&request)
+		if err != nil {
+			ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
+			return
+		}
+	}
+	request.ApprovalId = ctx.Param("approval-id")
+
+	response, err := c.service.SetSampleApproval(ctx, action, &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	resp := &dto.Response[*viewmodel.SampleApprovalRsViewModel]{
+		ResponseCode:    strconv.Itoa(http.StatusOK),
+		ResponseMessage: "Success",
+		LogReff:         identifier.GetLogReff(ctx),
+		TraceId:         identifier.GetTraceId(ctx),
+		Data:            response,
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
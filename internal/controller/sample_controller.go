@@ -4,7 +4,9 @@ import (
 	"gogin-template/baselib/dto"
 	"gogin-template/baselib/exception"
 	"gogin-template/baselib/identifier"
+	"gogin-template/baselib/middleware"
 	"gogin-template/bootstrap"
+	"gogin-template/internal/constants"
 	"gogin-template/internal/service"
 	"gogin-template/internal/viewmodel"
 	"net/http"
@@ -18,28 +20,36 @@ type SampleController struct {
 	cfg     *bootstrap.Container
 }
 
-func NewSampleController(service service.SampleService, server *gin.Engine, cfg *bootstrap.Container) {
+func NewSampleController(service service.SampleService, server gin.IRouter, cfg *bootstrap.Container) {
 	controller := &SampleController{
 		service: service,
 		cfg:     cfg,
 	}
 
+	read := middleware.RbacMiddleware(cfg, constants.PERMISSION_SAMPLE_READ)
+	write := middleware.RbacMiddleware(cfg, constants.PERMISSION_SAMPLE_WRITE)
+	remove := middleware.RbacMiddleware(cfg, constants.PERMISSION_SAMPLE_DELETE)
+	version := middleware.RbacMiddleware(cfg, constants.PERMISSION_SAMPLE_VERSION)
+
 	routes := server.Group("/sample")
 	{
-		routes.GET("", controller.GetSamples)
-		routes.GET("/:sample-id", controller.GetSample)
-		routes.GET("/:sample-id/version", controller.GetSampleVersions)
-		routes.GET("/:sample-id/version/:version-number", controller.GetSampleVersion)
-
-		routes.POST("", controller.SetSampleInsert)
-		routes.POST("/versions", controller.SetSampleVersions)
-		routes.POST("/version", controller.SetSampleVersionInsert)
-
-		routes.PUT("", controller.SetSampleUpsert)
-		routes.PUT("/version", controller.SetSampleVersionUpdate)
-
-		routes.DELETE("/:sample-id", controller.GetSample)
-		routes.DELETE("/:sample-id/version/:version-number", controller.SetSampleVersionDelete)
+		routes.GET("", read, controller.GetSamples)
+		routes.GET("/:sample-id", read, controller.GetSample)
+		routes.GET("/:sample-id/version", read, controller.GetSampleVersions)
+		routes.GET("/:sample-id/version/:version-number", read, controller.GetSampleVersion)
+
+		routes.POST("", write, controller.SetSampleInsert)
+		routes.POST("/versions", version, controller.SetSampleVersions)
+		routes.POST("/version", version, controller.SetSampleVersionInsert)
+		routes.POST("/:sample-id/version/:version-number/activate", version, controller.SetSampleVersionActivate)
+		routes.POST("/:sample-id/version/rollback", version, controller.SetSampleVersionRollback)
+		routes.POST("/:sample-id/release", version, controller.SetSampleRelease)
+
+		routes.PUT("", write, controller.SetSampleUpsert)
+		routes.PUT("/version", version, controller.SetSampleVersionUpdate)
+
+		routes.DELETE("/:sample-id", remove, controller.SetSampleDelete)
+		routes.DELETE("/:sample-id/version/:version-number", version, controller.SetSampleVersionDelete)
 	}
 }
 
@@ -54,21 +64,24 @@ func NewSampleController(service service.SampleService, server *gin.Engine, cfg
 // @Param       pageSize		query	int		false	"Page Size"
 // @Param       sortBy			query	string	false	"Sort By"
 // @Param       sortDirection	query	string	false	"Sort Direction"
-// @Success 	200	{object} 	dto.ApiResponse[*[]viewmodel.SampleRsViewModel]
-// @Failure 	500	{object} 	dto.ApiResponse[any]
+// @Param       cursor			query	string	false	"Keyset Cursor (start for the first page)"
+// @Param       filter			query	string	false	"Filter Expression (e.g. sampleType:in:Type_A,Type_B;createDate:gte:2024-01-01)"
+// @Success 	200	{object} 	dto.Response[[]viewmodel.SampleRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
 // @Router 		/sample 	[get]
 func (c *SampleController) GetSamples(ctx *gin.Context) {
 	var request viewmodel.SampleRqViewModel
 	err := ctx.ShouldBindQuery(&request)
 	if err != nil {
-		ctx.Error(exception.ValidationException(strconv.Itoa(http.StatusBadRequest), err.Error()))
+		ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
 		return
 	}
 
 	pagination := dto.PageRequest{}
 	err = ctx.ShouldBindQuery(&pagination)
 	if err != nil {
-		ctx.Error(exception.ValidationException(strconv.Itoa(http.StatusBadRequest), err.Error()))
+		ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
 		return
 	}
 
@@ -95,8 +108,9 @@ func (c *SampleController) GetSamples(ctx *gin.Context) {
 // @Tags 		Sample
 // @Produce  	json
 // @Param       sample-id		path  	string  true	"Sample ID"
-// @Success 	200	{object} 	dto.ApiResponse[*viewmodel.SampleRsViewModel]
-// @Failure 	500	{object} 	dto.ApiResponse[any]
+// @Success 	200	{object} 	dto.Response[viewmodel.SampleRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
 // @Router 		/sample/{sample-id} 	[get]
 func (c *SampleController) GetSample(ctx *gin.Context) {
 	sampleId := ctx.Param("sample-id")
@@ -125,8 +139,9 @@ func (c *SampleController) GetSample(ctx *gin.Context) {
 // @Tags 		Sample
 // @Produce  	json
 // @Param       sample-id		path  	string  true	"Sample ID"
-// @Success 	200	{object} 	dto.ApiResponse[*[]viewmodel.SampleVersionRsViewModel]
-// @Failure 	500	{object} 	dto.ApiResponse[any]
+// @Success 	200	{object} 	dto.Response[[]viewmodel.SampleVersionRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
 // @Router 		/sample/{sample-id}/version 	[get]
 func (c *SampleController) GetSampleVersions(ctx *gin.Context) {
 	sampleId := ctx.Param("sample-id")
@@ -156,8 +171,9 @@ func (c *SampleController) GetSampleVersions(ctx *gin.Context) {
 // @Produce  	json
 // @Param       sample-id			path  	string  true	"Sample ID"
 // @Param       version-number		path  	string  true	"Sample Version"
-// @Success 	200	{object} 	dto.ApiResponse[*viewmodel.SampleVersionRsViewModel]
-// @Failure 	500	{object} 	dto.ApiResponse[any]
+// @Success 	200	{object} 	dto.Response[viewmodel.SampleVersionRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
 // @Router 		/sample/{sample-id}/version/{version-number} 	[get]
 func (c *SampleController) GetSampleVersion(ctx *gin.Context) {
 	sampleId := ctx.Param("sample-id")
@@ -189,31 +205,33 @@ func (c *SampleController) GetSampleVersion(ctx *gin.Context) {
 // @Accept  	json
 // @Produce  	json
 // @Param       request			body 	viewmodel.SampleRqViewModel  true  "Sample"
-// @Success 	200	{object} 	dto.ApiResponse[*any]
-// @Failure 	500	{object} 	dto.ApiResponse[any]
+// @Success 	202	{object} 	dto.Response[viewmodel.SampleApprovalRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
 // @Router 		/sample [post]
 func (c *SampleController) SetSampleInsert(ctx *gin.Context) {
 	var request viewmodel.SampleRqViewModel
 	err := ctx.BindJSON(&request)
 	if err != nil {
-		ctx.Error(exception.ValidationException(strconv.Itoa(http.StatusBadRequest), err.Error()))
+		ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
 		return
 	}
 
-	err = c.service.SetSample(ctx, "Insert", &request)
+	response, err := c.service.SetSample(ctx, "Insert", &request)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
-	resp := &dto.Response[*any]{
-		ResponseCode:    strconv.Itoa(http.StatusOK),
-		ResponseMessage: "Success",
+	resp := &dto.Response[*viewmodel.SampleApprovalRsViewModel]{
+		ResponseCode:    strconv.Itoa(http.StatusAccepted),
+		ResponseMessage: "Pending Approval",
 		LogReff:         identifier.GetLogReff(ctx),
 		TraceId:         identifier.GetTraceId(ctx),
+		Data:            response,
 	}
 
-	ctx.JSON(http.StatusOK, resp)
+	ctx.JSON(http.StatusAccepted, resp)
 }
 
 // @Summary 	Set Sample Upsert
@@ -222,31 +240,33 @@ func (c *SampleController) SetSampleInsert(ctx *gin.Context) {
 // @Accept  	json
 // @Produce  	json
 // @Param       request body 	viewmodel.SampleRqViewModel  true  "Sample"
-// @Success 	200	{object} 	dto.ApiResponse[*any]
-// @Failure 	500	{object} 	dto.ApiResponse[any]
+// @Success 	202	{object} 	dto.Response[viewmodel.SampleApprovalRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
 // @Router 		/sample [put]
 func (c *SampleController) SetSampleUpsert(ctx *gin.Context) {
 	var request viewmodel.SampleRqViewModel
 	err := ctx.BindJSON(&request)
 	if err != nil {
-		ctx.Error(exception.ValidationException(strconv.Itoa(http.StatusBadRequest), err.Error()))
+		ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
 		return
 	}
 
-	err = c.service.SetSample(ctx, "Upsert", &request)
+	response, err := c.service.SetSample(ctx, "Upsert", &request)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
-	resp := &dto.Response[*any]{
-		ResponseCode:    strconv.Itoa(http.StatusOK),
-		ResponseMessage: "Success",
+	resp := &dto.Response[*viewmodel.SampleApprovalRsViewModel]{
+		ResponseCode:    strconv.Itoa(http.StatusAccepted),
+		ResponseMessage: "Pending Approval",
 		LogReff:         identifier.GetLogReff(ctx),
 		TraceId:         identifier.GetTraceId(ctx),
+		Data:            response,
 	}
 
-	ctx.JSON(http.StatusOK, resp)
+	ctx.JSON(http.StatusAccepted, resp)
 }
 
 // @Summary 	Set Sample Delete
@@ -255,28 +275,31 @@ func (c *SampleController) SetSampleUpsert(ctx *gin.Context) {
 // @Accept  	json
 // @Produce  	json
 // @Param       sample-id		path  	string	true	"Sample ID"
-// @Success 	200	{object} 	dto.ApiResponse[*any]
-// @Failure 	500	{object} 	dto.ApiResponse[any]
+// @Param       updateUser		query  	string	true	"Request User ID"
+// @Success 	202	{object} 	dto.Response[viewmodel.SampleApprovalRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
 // @Router 		/sample/{sample-id} [delete]
 func (c *SampleController) SetSampleDelete(ctx *gin.Context) {
 	sampleId := ctx.Param("sample-id")
 
-	request := &viewmodel.SampleRqViewModel{SampleId: sampleId}
+	request := &viewmodel.SampleRqViewModel{SampleId: sampleId, UpdateUser: ctx.Query("updateUser")}
 
-	err := c.service.SetSample(ctx, "Delete", request)
+	response, err := c.service.SetSample(ctx, "Delete", request)
 	if err != nil {
 		ctx.Error(err)
 		return
 	}
 
-	resp := &dto.Response[*any]{
-		ResponseCode:    strconv.Itoa(http.StatusOK),
-		ResponseMessage: "Success",
+	resp := &dto.Response[*viewmodel.SampleApprovalRsViewModel]{
+		ResponseCode:    strconv.Itoa(http.StatusAccepted),
+		ResponseMessage: "Pending Approval",
 		LogReff:         identifier.GetLogReff(ctx),
 		TraceId:         identifier.GetTraceId(ctx),
+		Data:            response,
 	}
 
-	ctx.JSON(http.StatusOK, resp)
+	ctx.JSON(http.StatusAccepted, resp)
 }
 
 // @Summary 	Set Sample Versions
@@ -285,14 +308,15 @@ func (c *SampleController) SetSampleDelete(ctx *gin.Context) {
 // @Accept  	json
 // @Produce  	json
 // @Param       request			body 	[]viewmodel.SampleVersionRqViewModel  true  "Sample Version"
-// @Success 	200	{object} 	dto.ApiResponse[*any]
-// @Failure 	500	{object} 	dto.ApiResponse[any]
+// @Success 	200	{object} 	dto.Response[any]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
 // @Router 		/sample/versions [post]
 func (c *SampleController) SetSampleVersions(ctx *gin.Context) {
 	var request []viewmodel.SampleVersionRqViewModel
 	err := ctx.BindJSON(&request)
 	if err != nil {
-		ctx.Error(exception.ValidationException(strconv.Itoa(http.StatusBadRequest), err.Error()))
+		ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
 		return
 	}
 
@@ -318,14 +342,15 @@ func (c *SampleController) SetSampleVersions(ctx *gin.Context) {
 // @Accept  	json
 // @Produce  	json
 // @Param       request			body 	viewmodel.SampleVersionRqViewModel  true  "Sample Version"
-// @Success 	200	{object} 	dto.ApiResponse[*any]
-// @Failure 	500	{object} 	dto.ApiResponse[any]
+// @Success 	200	{object} 	dto.Response[any]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
 // @Router 		/sample/version [post]
 func (c *SampleController) SetSampleVersionInsert(ctx *gin.Context) {
 	var request viewmodel.SampleVersionRqViewModel
 	err := ctx.BindJSON(&request)
 	if err != nil {
-		ctx.Error(exception.ValidationException(strconv.Itoa(http.StatusBadRequest), err.Error()))
+		ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
 		return
 	}
 
@@ -351,14 +376,15 @@ func (c *SampleController) SetSampleVersionInsert(ctx *gin.Context) {
 // @Accept  	json
 // @Produce  	json
 // @Param       request			body 	viewmodel.SampleVersionRqViewModel  true  "Sample Version"
-// @Success 	200	{object} 	dto.ApiResponse[*any]
-// @Failure 	500	{object} 	dto.ApiResponse[any]
+// @Success 	200	{object} 	dto.Response[any]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
 // @Router 		/sample/version [put]
 func (c *SampleController) SetSampleVersionUpdate(ctx *gin.Context) {
 	var request viewmodel.SampleVersionRqViewModel
 	err := ctx.BindJSON(&request)
 	if err != nil {
-		ctx.Error(exception.ValidationException(strconv.Itoa(http.StatusBadRequest), err.Error()))
+		ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
 		return
 	}
 
@@ -384,8 +410,9 @@ func (c *SampleController) SetSampleVersionUpdate(ctx *gin.Context) {
 // @Produce  	json
 // @Param       sample-id			path  	string  true	"Sample ID"
 // @Param       version-number		path  	string  true	"Sample Version"
-// @Success 	200	{object} 	dto.ApiResponse[*any]
-// @Failure 	500	{object} 	dto.ApiResponse[any]
+// @Success 	200	{object} 	dto.Response[any]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
 // @Router 		/sample/{sample-id}/version/{version-number} 	[delete]
 func (c *SampleController) SetSampleVersionDelete(ctx *gin.Context) {
 	sampleId := ctx.Param("sample-id")
@@ -408,3 +435,100 @@ func (c *SampleController) SetSampleVersionDelete(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, resp)
 }
+
+// @Summary 	Set Sample Version Activate
+// @Description Set Sample Version Activate
+// @Tags 		Sample
+// @Accept  	json
+// @Produce  	json
+// @Param       sample-id			path  	string  true	"Sample ID"
+// @Param       version-number		path  	string  true	"Sample Version"
+// @Param       request				body 	viewmodel.SampleVersionRqViewModel  false  "Sample Version (updateUser)"
+// @Success 	200	{object} 	dto.Response[viewmodel.SampleRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
+// @Router 		/sample/{sample-id}/version/{version-number}/activate 	[post]
+func (c *SampleController) SetSampleVersionActivate(ctx *gin.Context) {
+	c.setSampleActiveVersion(ctx, "Activate")
+}
+
+// @Summary 	Set Sample Version Rollback
+// @Description Restore the version that was active before the latest activation
+// @Tags 		Sample
+// @Accept  	json
+// @Produce  	json
+// @Param       sample-id			path  	string  true	"Sample ID"
+// @Param       request				body 	viewmodel.SampleVersionRqViewModel  false  "Sample Version (updateUser)"
+// @Success 	200	{object} 	dto.Response[viewmodel.SampleRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
+// @Router 		/sample/{sample-id}/version/rollback 	[post]
+func (c *SampleController) SetSampleVersionRollback(ctx *gin.Context) {
+	c.setSampleActiveVersion(ctx, "Rollback")
+}
+
+// @Summary 	Set Sample Release
+// @Description Insert new versions and activate one of them, all or nothing
+// @Tags 		Sample
+// @Accept  	json
+// @Produce  	json
+// @Param       sample-id			path  	string  true	"Sample ID"
+// @Param       request				body 	viewmodel.SampleReleaseRqViewModel  true  "Sample Release"
+// @Success 	200	{object} 	dto.Response[viewmodel.SampleRsViewModel]
+// @Failure 	500	{object} 	dto.Response[any]
+// @Security 	BearerAuth
+// @Router 		/sample/{sample-id}/release 	[post]
+func (c *SampleController) SetSampleRelease(ctx *gin.Context) {
+	var request viewmodel.SampleReleaseRqViewModel
+	err := ctx.BindJSON(&request)
+	if err != nil {
+		ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
+		return
+	}
+	request.SampleId = ctx.Param("sample-id")
+
+	response, err := c.service.SetSampleRelease(ctx, &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	resp := &dto.Response[*viewmodel.SampleRsViewModel]{
+		ResponseCode:    strconv.Itoa(http.StatusOK),
+		ResponseMessage: "Success",
+		LogReff:         identifier.GetLogReff(ctx),
+		TraceId:         identifier.GetTraceId(ctx),
+		Data:            response,
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+func (c *SampleController) setSampleActiveVersion(ctx *gin.Context, action string) {
+	var request viewmodel.SampleVersionRqViewModel
+	if ctx.Request.ContentLength != 0 {
+		err := ctx.ShouldBindJSON(&request)
+		if err != nil {
+			ctx.Error(exception.New(exception.ERROR_INVALID_REQUEST, nil).WithCause(err))
+			return
+		}
+	}
+	request.SampleId = ctx.Param("sample-id")
+	request.VersionNumber = ctx.Param("version-number")
+
+	response, err := c.service.SetSampleActiveVersion(ctx, action, &request)
+	if err != nil {
+		ctx.Error(err)
+		return
+	}
+
+	resp := &dto.Response[*viewmodel.SampleRsViewModel]{
+		ResponseCode:    strconv.Itoa(http.StatusOK),
+		ResponseMessage: "Success",
+		LogReff:         identifier.GetLogReff(ctx),
+		TraceId:         identifier.GetTraceId(ctx),
+		Data:            response,
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,22 @@
+package model
+
+import "time"
+
+type SampleApprovalQueryModel struct {
+	ApprovalId     string
+	SampleId       string
+	ApprovalStatus string
+}
+
+type SampleApprovalModel struct {
+	ApprovalId     string     `db:"approval_id" dbx:"key" validate:"omitempty,max=36"`
+	SampleId       string     `db:"sample_id" dbx:"sort" validate:"omitempty,max=20"`
+	ApprovalAction string     `db:"approval_action" dbx:"sort" validate:"omitempty,max=10"`
+	ApprovalStatus string     `db:"approval_status" dbx:"sort" validate:"omitempty,max=10"`
+	ApprovalData   *string    `db:"approval_data"`
+	ApprovalReason *string    `db:"approval_reason" validate:"omitempty,max=1000"`
+	RequestDate    *time.Time `db:"request_date" dbx:"sort"`
+	RequestUser    string     `db:"request_user" validate:"omitempty,max=10"`
+	ApprovalDate   *time.Time `db:"approval_date"`
+	ApprovalUser   *string    `db:"approval_user" validate:"omitempty,max=10"`
+}
@@ -29,7 +29,7 @@ type SampleVersionQueryModel struct {
 
 type SampleVersionModel struct {
 	SampleId       string     `db:"sample_id" dbx:"key,foreign" validate:"omitempty,max=20"`
-	VersionNumber  string     `db:"version_number" dbx:"key" validate:"omitempty,max=10"`
+	VersionNumber  string     `db:"version_number" dbx:"key" validate:"omitempty,max=10,version"`
 	CreateDate     *time.Time `db:"create_date"`
 	CreateUser     string     `db:"create_user" validate:"omitempty,max=10"`
 	CreateApprover string     `db:"create_approver" validate:"omitempty,max=10"`
@@ -37,3 +37,17 @@ type SampleVersionModel struct {
 	UpdateUser     string     `db:"update_user" validate:"omitempty,max=10"`
 	UpdateApprover string     `db:"update_approver" validate:"omitempty,max=10"`
 }
+
+type SampleVersionHistoryQueryModel struct {
+	SampleId string
+}
+
+type SampleVersionHistoryModel struct {
+	HistoryId       string     `db:"history_id" dbx:"key" validate:"omitempty,max=36"`
+	SampleId        string     `db:"sample_id" dbx:"foreign" validate:"omitempty,max=20"`
+	VersionNumber   string     `db:"version_number" validate:"omitempty,max=10"`
+	PreviousVersion string     `db:"previous_version" validate:"omitempty,max=10"`
+	HistoryAction   string     `db:"history_action" validate:"omitempty,max=10"`
+	CreateDate      *time.Time `db:"create_date" dbx:"sort"`
+	CreateUser      string     `db:"create_user" validate:"omitempty,max=10"`
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"gogin-template/baselib/dto"
+	"gogin-template/baselib/helper"
+	"gogin-template/bootstrap"
+	"gogin-template/internal/model"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jmoiron/sqlx"
+)
+
+// ErrApprovalPending is returned when staging an approval for a sample that
+// already has a pending one.
+var ErrApprovalPending = errors.New("sample already has a pending approval")
+
+type SampleApprovalRepository interface {
+	GetSampleApprovals(c context.Context, obj *model.SampleApprovalQueryModel, dtoPage dto.PageRequest) (*[]model.SampleApprovalModel, *dto.PageInfo, error)
+	GetSampleApproval(c context.Context, obj *model.SampleApprovalQueryModel) (*model.SampleApprovalModel, error)
+	SetSampleApproval(c context.Context, action string, obj *model.SampleApprovalModel) error
+	SetSampleApprovalDecision(c context.Context, fromStatus string, obj *model.SampleApprovalModel) (bool, error)
+}
+
+type SampleApprovalRepositoryImpl struct {
+	dbr      *sqlx.DB
+	dbw      *sqlx.DB
+	pgr      *pgxpool.Pool
+	pgw      *pgxpool.Pool
+	cfg      *bootstrap.Container
+	queryMap map[string]string
+	schema   string
+}
+
+func NewSampleApprovalRepository(dbr *sqlx.DB, dbw *sqlx.DB, pgr *pgxpool.Pool, pgw *pgxpool.Pool, cfg *bootstrap.Container) SampleApprovalRepository {
+	queryMap := map[string]string{}
+	columns := ""
+	schema := "sample"
+
+	// Initialize Query Map
+	_, columns, _, _, _ = helper.RepoPGGetColumns(reflect.TypeOf(model.SampleApprovalModel{}))
+	queryMap["GetSampleApprovals"] = `SELECT ` + columns + ` `
+
+	queryMap["SetSampleApproval"] = helper.RepoPGGetInsert(reflect.TypeOf(model.SampleApprovalModel{}), schema, "sample_approval")
+
+	queryMap["UpdateSampleApproval"] = helper.RepoPGGetUpsert(reflect.TypeOf(model.SampleApprovalModel{}), schema, "sample_approval")
+
+	queryMap["SetSampleApprovalDecision"] = `UPDATE ` + schema + `.sample_approval
+	SET approval_status = $2, approval_reason = $3, approval_date = $4, approval_user = $5
+	WHERE approval_id = $1 AND approval_status = $6`
+
+	return &SampleApprovalRepositoryImpl{dbr: dbr, dbw: dbw, cfg: cfg, pgr: pgr, pgw: pgw, queryMap: queryMap, schema: schema}
+}
+
+func (r *SampleApprovalRepositoryImpl) GetSampleApprovals(c context.Context, obj *model.SampleApprovalQueryModel, dtoPage dto.PageRequest) (*[]model.SampleApprovalModel, *dto.PageInfo, error) {
+	// Set Base Query
+	var data model.SampleApprovalModel
+	result := []model.SampleApprovalModel{}
+
+	selectQuery := r.queryMap["GetSampleApprovals"]
+	baseKey, _, _, _, allowedOrder := helper.RepoPGGetColumns(reflect.TypeOf(data))
+	limit, offset := helper.GetLimitAndOffset(dtoPage.PageSize, dtoPage.Page)
+	baseQuery := `
+	FROM ` + r.schema + `.sample_approval
+	WHERE ($1::text is NULL OR $1::text = '' OR approval_id = $1::text)
+	AND ($2::text is NULL OR $2::text = '' OR sample_id = $2::text)
+	AND ($3::text is NULL OR $3::text = '' OR approval_status = $3::text)
+	`
+	filters := []interface{}{obj.ApprovalId, obj.SampleId, obj.ApprovalStatus}
+	filterQuery, filterArgs, err := helper.RepoPGGetFilter(reflect.TypeOf(data), dtoPage.Filter, len(filters)+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	baseQuery += filterQuery
+	filters = append(filters, filterArgs...)
+
+	query := selectQuery + baseQuery + ` ORDER BY ` + dtoPage.GetOrderString(baseKey, allowedOrder) +
+		` LIMIT $` + strconv.Itoa(len(filters)+1) + `::int OFFSET $` + strconv.Itoa(len(filters)+2) + `::int`
+	args := append(filters, limit, offset)
+
+	// Keyset Mode
+	var keyset *helper.Keyset
+	if dtoPage.IsKeyset() {
+		keyset, err = helper.RepoPGGetKeyset(reflect.TypeOf(data), dtoPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		query = selectQuery + baseQuery + keyset.Query(len(filters)+1)
+		args = append(filters, keyset.Args...)
+	}
+
+	// Get Max Page
+	dbr, err := r.cfg.SqlxRead(c, r.dbr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var totalData int
+	queryCount := `SELECT count(1) ` + baseQuery
+	err = dbr.QueryRowxContext(c, queryCount, filters...).Scan(&totalData)
+	if err != nil {
+		return nil, nil, err
+	}
+	pageInfo := dtoPage.GetPageInfo(totalData)
+
+	// Get Data
+	rows, err := dbr.QueryxContext(c, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	// Convert to Struct
+	for rows.Next() {
+		data = model.SampleApprovalModel{}
+		err = rows.StructScan(&data)
+		if err != nil {
+			return nil, nil, err
+		}
+		result = append(result, data)
+	}
+
+	if keyset != nil {
+		result = helper.RepoPGGetKeysetPage(keyset, result, &pageInfo)
+	}
+
+	return &result, &pageInfo, nil
+}
+
+func (r *SampleApprovalRepositoryImpl) GetSampleApproval(c context.Context, obj *model.SampleApprovalQueryModel) (*model.SampleApprovalModel, error) {
+	list, _, err := r.GetSampleApprovals(c, obj, dto.PageRequest{PageSize: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(*list) == 0 {
+		return nil, nil
+	}
+
+	return &(*list)[0], nil
+}
+
+func (r *SampleApprovalRepositoryImpl) SetSampleApproval(c context.Context, action string, obj *model.SampleApprovalModel) error {
+	dbw, err := r.cfg.Sqlx(c, r.dbw)
+	if err != nil {
+		return err
+	}
+
+	if strings.HasPrefix(action, "I") {
+		query := r.queryMap["SetSampleApproval"]
+		values := helper.RepoPGGetTypeArgValue(*obj)
+		_, err = dbw.ExecContext(c, query, values...)
+		if helper.RepoPGIsUniqueViolation(err, "sample_approval_pending_uq") {
+			return ErrApprovalPending
+		}
+	} else if strings.HasPrefix(action, "U") {
+		query := r.queryMap["UpdateSampleApproval"]
+		values := helper.RepoPGGetTypeArgValue(*obj)
+		_, err = dbw.ExecContext(c, query, values...)
+	}
+
+	return err
+}
+
+// SetSampleApprovalDecision moves an approval out of fromStatus and reports
+// whether it did, so two checkers racing on the same draft cannot both win.
+func (r *SampleApprovalRepositoryImpl) SetSampleApprovalDecision(c context.Context, fromStatus string, obj *model.SampleApprovalModel) (bool, error) {
+	dbw, err := r.cfg.Sqlx(c, r.dbw)
+	if err != nil {
+		return false, err
+	}
+
+	query := r.queryMap["SetSampleApprovalDecision"]
+	res, err := dbw.ExecContext(c, query, obj.ApprovalId, obj.ApprovalStatus, obj.ApprovalReason, obj.ApprovalDate, obj.ApprovalUser, fromStatus)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
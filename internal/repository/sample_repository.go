@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"gogin-template/baselib/dto"
 	"gogin-template/baselib/helper"
 	"gogin-template/bootstrap"
 	"gogin-template/internal/model"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/jackc/pgx/v5"
@@ -17,11 +20,14 @@ import (
 type SampleRepository interface {
 	GetSamples(c context.Context, obj *model.SampleQueryModel, dtoPage dto.PageRequest) (*[]model.SampleModel, *dto.PageInfo, error)
 	GetSample(c context.Context, obj *model.SampleQueryModel) (*model.SampleModel, error)
+	LockSample(c context.Context, obj *model.SampleQueryModel) (*model.SampleModel, error)
 	GetSampleVersions(c context.Context, obj *model.SampleVersionQueryModel) (*[]model.SampleVersionModel, error)
 	GetSampleVersion(c context.Context, obj *model.SampleVersionQueryModel) (*model.SampleVersionModel, error)
 	SetSample(c context.Context, action string, obj *model.SampleModel) error
 	SetSampleVersions(c context.Context, obj *[]model.SampleVersionModel) error
 	SetSampleVersion(c context.Context, action string, obj *model.SampleVersionModel) error
+	GetSampleVersionHistories(c context.Context, obj *model.SampleVersionHistoryQueryModel) (*[]model.SampleVersionHistoryModel, error)
+	SetSampleActiveVersion(c context.Context, obj *model.SampleModel, history *model.SampleVersionHistoryModel) error
 }
 
 type SampleRepositoryImpl struct {
@@ -42,6 +48,7 @@ func NewSampleRepository(dbr *sqlx.DB, dbw *sqlx.DB, pgr *pgxpool.Pool, pgw *pgx
 	// Initialize Query Map
 	_, columns, _, _, _ = helper.RepoPGGetColumns(reflect.TypeOf(model.SampleModel{}))
 	queryMap["GetSamples"] = `SELECT ` + columns + ` `
+	queryMap["LockSample"] = `SELECT ` + columns + ` FROM ` + schema + `.sample WHERE sample_id = $1 FOR UPDATE`
 
 	_, columns, _, _, _ = helper.RepoPGGetColumns(reflect.TypeOf(model.SampleVersionModel{}))
 	queryMap["GetSampleVersions"] = `SELECT ` + columns + ` `
@@ -58,6 +65,15 @@ func NewSampleRepository(dbr *sqlx.DB, dbw *sqlx.DB, pgr *pgxpool.Pool, pgw *pgx
 
 	queryMap["DeleteSampleVersion"] = helper.RepoPGGetDelete(reflect.TypeOf(model.SampleVersionModel{}), schema, "sample_version")
 
+	_, columns, _, _, _ = helper.RepoPGGetColumns(reflect.TypeOf(model.SampleVersionHistoryModel{}))
+	queryMap["GetSampleVersionHistories"] = `SELECT ` + columns + ` FROM ` + schema + `.sample_version_history WHERE sample_id = $1 ORDER BY create_date DESC`
+
+	queryMap["SetSampleVersionHistory"] = helper.RepoPGGetInsert(reflect.TypeOf(model.SampleVersionHistoryModel{}), schema, "sample_version_history")
+
+	queryMap["SetSampleActiveVersion"] = `UPDATE ` + schema + `.sample
+	SET sample_active_version = $2, update_date = $3, update_user = $4
+	WHERE sample_id = $1 AND coalesce(sample_active_version, '') = $5`
+
 	return &SampleRepositoryImpl{dbr: dbr, dbw: dbw, cfg: cfg, pgr: pgr, pgw: pgw, queryMap: queryMap, schema: schema}
 }
 
@@ -78,61 +94,111 @@ func (r *SampleRepositoryImpl) GetSamples(c context.Context, obj *model.SampleQu
 	OR lower(sample_type) like lower($3::text)
 	OR lower(sample_description) like lower($3::text))
 	`
-	orderString := ` ORDER BY ` + dtoPage.GetOrderString(baseKey, allowedOrder) + ` LIMIT $4::int OFFSET $5::int`
-	query := selectQuery + baseQuery + orderString
+	filters := []interface{}{obj.SampleId, obj.SampleType, "%" + dtoPage.Query + "%"}
+	filterQuery, filterArgs, err := helper.RepoPGGetFilter(reflect.TypeOf(data), dtoPage.Filter, len(filters)+1)
+	if err != nil {
+		return nil, nil, err
+	}
+	baseQuery += filterQuery
+	filters = append(filters, filterArgs...)
+
+	query := selectQuery + baseQuery + ` ORDER BY ` + dtoPage.GetOrderString(baseKey, allowedOrder) +
+		` LIMIT $` + strconv.Itoa(len(filters)+1) + `::int OFFSET $` + strconv.Itoa(len(filters)+2) + `::int`
+	args := append(filters, limit, offset)
+
+	// Keyset Mode
+	var keyset *helper.Keyset
+	if dtoPage.IsKeyset() {
+		keyset, err = helper.RepoPGGetKeyset(reflect.TypeOf(data), dtoPage)
+		if err != nil {
+			return nil, nil, err
+		}
+		query = selectQuery + baseQuery + keyset.Query(len(filters)+1)
+		args = append(filters, keyset.Args...)
+	}
 
 	// Get Max Page
+	dbw, err := r.cfg.SqlxRead(c, r.dbw)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	var totalData int
 	queryCount := `SELECT count(1) ` + baseQuery
-	err := r.dbw.QueryRowxContext(c, queryCount, obj.SampleId, obj.SampleType, "%"+dtoPage.Query+"%").Scan(&totalData)
+	err = dbw.QueryRowxContext(c, queryCount, filters...).Scan(&totalData)
 	if err != nil {
 		return nil, nil, err
 	}
 	pageInfo := dtoPage.GetPageInfo(totalData)
 
 	// Get Data
-	rows, err := r.dbr.QueryxContext(c, query, obj.SampleId, obj.SampleType, "%"+dtoPage.Query+"%", limit, offset)
+	dbr, err := r.cfg.SqlxRead(c, r.dbr)
 	if err != nil {
 		return nil, nil, err
 	}
-	defer rows.Close()
 
-	// Convert to Struct
-	for rows.Next() {
-		err = rows.StructScan(&data)
-		if err != nil {
-			return nil, nil, err
-		}
+	err = sqlx.SelectContext(c, dbr, &result, query, args...)
+	if err != nil {
+		return nil, nil, err
+	}
 
+	// Versions are read once the rows are closed, a unit of work runs every
+	// query on one connection
+	for i := range result {
 		versionQuery := &model.SampleVersionQueryModel{SampleId: obj.SampleId}
 
 		versions, err := r.GetSampleVersions(c, versionQuery)
 		if err != nil {
 			return nil, nil, err
 		}
-		data.SampleVersions = versions
+		result[i].SampleVersions = versions
+	}
 
-		result = append(result, data)
+	if keyset != nil {
+		result = helper.RepoPGGetKeysetPage(keyset, result, &pageInfo)
 	}
 
 	return &result, &pageInfo, nil
 }
 
+// GetSample returns nil when no sample matches obj.
 func (r *SampleRepositoryImpl) GetSample(c context.Context, obj *model.SampleQueryModel) (*model.SampleModel, error) {
-	var result model.SampleModel
-	var list *[]model.SampleModel
-	var err error
+	list, _, err := r.GetSamples(c, obj, dto.PageRequest{PageSize: 1})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(*list) == 0 {
+		return nil, nil
+	}
 
-	list, _, err = r.GetSamples(c, obj, dto.PageRequest{PageSize: 1})
+	return &(*list)[0], nil
+}
+
+// LockSample reads the sample with its versions and locks its row until the
+// unit of work of c ends, so a change based on what was read cannot be lost
+// to a concurrent one. It returns nil when the sample does not exist.
+func (r *SampleRepositoryImpl) LockSample(c context.Context, obj *model.SampleQueryModel) (*model.SampleModel, error) {
+	dbw, err := r.cfg.Sqlx(c, r.dbw)
 	if err != nil {
 		return nil, err
 	}
 
-	if len(*list) > 0 {
-		result = (*list)[0]
+	result := model.SampleModel{}
+	err = sqlx.GetContext(c, dbw, &result, r.queryMap["LockSample"], obj.SampleId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
 	}
 
-	return &result, err
+	result.SampleVersions, err = r.GetSampleVersions(c, &model.SampleVersionQueryModel{SampleId: result.SampleId})
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
 }
 
 func (r *SampleRepositoryImpl) GetSampleVersions(c context.Context, obj *model.SampleVersionQueryModel) (*[]model.SampleVersionModel, error) {
@@ -143,12 +209,17 @@ func (r *SampleRepositoryImpl) GetSampleVersions(c context.Context, obj *model.S
 	baseQuery := `
 	FROM ` + r.schema + `.sample_version 
 		WHERE ($1::text is NULL OR $1::text = '' OR sample_id = $1::text) 
-		AND ($2::text is NULL OR $2::text = '' OR sample_version = $2::text)
+		AND ($2::text is NULL OR $2::text = '' OR version_number = $2::text)
 	`
 	query := selectQuery + baseQuery
 
 	// Get Data
-	rows, err := r.dbr.QueryxContext(c, query, obj.SampleId, obj.SampleVersions)
+	dbr, err := r.cfg.SqlxRead(c, r.dbr)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := dbr.QueryxContext(c, query, obj.SampleId, obj.SampleVersions)
 	if err != nil {
 		return nil, err
 	}
@@ -184,65 +255,115 @@ func (r *SampleRepositoryImpl) GetSampleVersion(c context.Context, obj *model.Sa
 }
 
 func (r *SampleRepositoryImpl) SetSample(c context.Context, action string, obj *model.SampleModel) error {
-	var err error
+	dbw, err := r.cfg.Sqlx(c, r.dbw)
+	if err != nil {
+		return err
+	}
 
 	if strings.HasPrefix(action, "I") {
 		query := r.queryMap["SetSample"]
 		values := helper.RepoPGGetTypeArgValue(*obj)
-		_, err = r.dbw.ExecContext(c, query, values...)
+		_, err = dbw.ExecContext(c, query, values...)
 	} else if strings.HasPrefix(action, "U") {
 		query := r.queryMap["UpdateSample"]
 		values := helper.RepoPGGetTypeArgValue(*obj)
-		_, err = r.dbw.ExecContext(c, query, values...)
+		_, err = dbw.ExecContext(c, query, values...)
 	} else if strings.HasPrefix(action, "D") {
 		query := r.queryMap["DeleteSample"]
-		_, err = r.dbw.ExecContext(c, query, obj.SampleId)
+		_, err = dbw.ExecContext(c, query, obj.SampleId)
 	}
 
 	return err
 }
 
+// SetSampleVersions inserts the versions in one batch, within the unit of work
+// of c or a transaction of its own.
 func (r *SampleRepositoryImpl) SetSampleVersions(c context.Context, obj *[]model.SampleVersionModel) error {
-	var err error
 	batch := pgx.Batch{}
-
-	tx, err := r.pgw.BeginTx(c, pgx.TxOptions{})
-	if err != nil {
-		return err
-	}
-	defer tx.Rollback(c)
-
 	for _, data := range *obj {
 		query := r.queryMap["SetSampleVersion"]
 		values := helper.RepoPGGetTypeArgValue(data)
 		batch.Queue(query, values...)
 	}
 
-	if batch.Len() > 0 {
-		err := r.pgw.SendBatch(c, &batch).Close()
+	if batch.Len() == 0 {
+		return nil
+	}
+
+	return r.cfg.Transaction(c, func(c context.Context) error {
+		pgw, err := r.cfg.Pgx(c, r.pgw)
 		if err != nil {
 			return err
 		}
-	}
 
-	return err
+		return pgw.SendBatch(c, &batch).Close()
+	})
 }
 
 func (r *SampleRepositoryImpl) SetSampleVersion(c context.Context, action string, obj *model.SampleVersionModel) error {
-	var err error
+	dbw, err := r.cfg.Sqlx(c, r.dbw)
+	if err != nil {
+		return err
+	}
 
 	if strings.HasPrefix(action, "I") {
 		query := r.queryMap["SetSampleVersion"]
 		values := helper.RepoPGGetTypeArgValue(*obj)
-		_, err = r.dbw.ExecContext(c, query, values...)
+		_, err = dbw.ExecContext(c, query, values...)
 	} else if strings.HasPrefix(action, "U") {
 		query := r.queryMap["UpdateSampleVersion"]
 		values := helper.RepoPGGetTypeArgValue(*obj)
-		_, err = r.dbw.ExecContext(c, query, values...)
+		_, err = dbw.ExecContext(c, query, values...)
 	} else if strings.HasPrefix(action, "D") {
 		query := r.queryMap["DeleteSampleVersion"]
-		_, err = r.dbw.ExecContext(c, query, obj.SampleId, obj.VersionNumber)
+		_, err = dbw.ExecContext(c, query, obj.SampleId, obj.VersionNumber)
 	}
 
 	return err
 }
+
+func (r *SampleRepositoryImpl) GetSampleVersionHistories(c context.Context, obj *model.SampleVersionHistoryQueryModel) (*[]model.SampleVersionHistoryModel, error) {
+	result := []model.SampleVersionHistoryModel{}
+
+	dbr, err := r.cfg.SqlxRead(c, r.dbr)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sqlx.SelectContext(c, dbr, &result, r.queryMap["GetSampleVersionHistories"], obj.SampleId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SetSampleActiveVersion switches the active version and records the history
+// entry in one transaction. The switch only happens while the active version is
+// still history.PreviousVersion, so concurrent switches cannot overwrite each other.
+func (r *SampleRepositoryImpl) SetSampleActiveVersion(c context.Context, obj *model.SampleModel, history *model.SampleVersionHistoryModel) error {
+	return r.cfg.Transaction(c, func(c context.Context) error {
+		dbw, err := r.cfg.Sqlx(c, r.dbw)
+		if err != nil {
+			return err
+		}
+
+		res, err := dbw.ExecContext(c, r.queryMap["SetSampleActiveVersion"], obj.SampleId, obj.SampleActiveVersion, obj.UpdateDate, obj.UpdateUser, history.PreviousVersion)
+		if err != nil {
+			return err
+		}
+
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+
+		if affected == 0 {
+			return sql.ErrNoRows
+		}
+
+		values := helper.RepoPGGetTypeArgValue(*history)
+		_, err = dbw.ExecContext(c, r.queryMap["SetSampleVersionHistory"], values...)
+		return err
+	})
+}
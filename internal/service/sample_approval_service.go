@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"gogin-template/baselib/dto"
+	"gogin-template/baselib/exception"
+	"gogin-template/baselib/helper"
+	"gogin-template/bootstrap"
+	"gogin-template/internal/constants"
+	"gogin-template/internal/model"
+	"gogin-template/internal/repository"
+	"gogin-template/internal/viewmodel"
+	"strings"
+	"time"
+)
+
+type SampleApprovalService interface {
+	GetSampleApprovals(c context.Context, requestVM *viewmodel.SampleApprovalRqViewModel, dtoPage dto.PageRequest) (*[]viewmodel.SampleApprovalRsViewModel, *dto.PageInfo, error)
+	GetSampleApproval(c context.Context, requestVM *viewmodel.SampleApprovalRqViewModel) (*viewmodel.SampleApprovalRsViewModel, error)
+	SetSampleApproval(c context.Context, action string, requestVM *viewmodel.SampleApprovalRqViewModel) (*viewmodel.SampleApprovalRsViewModel, error)
+}
+
+type SampleApprovalServiceImpl struct {
+	repository       repository.SampleApprovalRepository
+	sampleRepository repository.SampleRepository
+	cfg              *bootstrap.Container
+}
+
+func NewSampleApprovalService(repository repository.SampleApprovalRepository, sampleRepository repository.SampleRepository, cfg *bootstrap.Container) SampleApprovalService {
+	return &SampleApprovalServiceImpl{repository: repository, sampleRepository: sampleRepository, cfg: cfg}
+}
+
+func (s *SampleApprovalServiceImpl) GetSampleApprovals(c context.Context, requestVM *viewmodel.SampleApprovalRqViewModel, dtoPage dto.PageRequest) (*[]viewmodel.SampleApprovalRsViewModel, *dto.PageInfo, error) {
+	// Convert View Model to Model
+	requestM := &model.SampleApprovalQueryModel{}
+
+	s.cfg.CopyStruct(requestVM, requestM)
+
+	// Process
+	response, pageInfo, err := s.repository.GetSampleApprovals(c, requestM, dtoPage)
+	if err != nil {
+		return nil, nil, helper.CatchErr(err)
+	}
+
+	// Convert To View Model
+	responseVM := []viewmodel.SampleApprovalRsViewModel{}
+	for i := range *response {
+		responseVM = append(responseVM, *toSampleApprovalViewModel(s.cfg, &(*response)[i]))
+	}
+
+	return &responseVM, pageInfo, nil
+}
+
+func (s *SampleApprovalServiceImpl) GetSampleApproval(c context.Context, requestVM *viewmodel.SampleApprovalRqViewModel) (*viewmodel.SampleApprovalRsViewModel, error) {
+	// Convert View Model to Model
+	requestM := &model.SampleApprovalQueryModel{ApprovalId: requestVM.ApprovalId}
+
+	// Process
+	response, err := s.repository.GetSampleApproval(c, requestM)
+	if err != nil {
+		return nil, helper.CatchErr(err)
+	}
+
+	if response == nil {
+		return nil, exception.New(constants.ERROR_APPROVAL_NOT_FOUND, exception.Params{"approvalId": requestVM.ApprovalId})
+	}
+
+	// Convert To View Model
+	return toSampleApprovalViewModel(s.cfg, response), nil
+}
+
+func (s *SampleApprovalServiceImpl) SetSampleApproval(c context.Context, action string, requestVM *viewmodel.SampleApprovalRqViewModel) (*viewmodel.SampleApprovalRsViewModel, error) {
+	// Validate
+	approval, err := s.repository.GetSampleApproval(c, &model.SampleApprovalQueryModel{ApprovalId: requestVM.ApprovalId})
+	if err != nil {
+		return nil, helper.CatchErr(err)
+	}
+
+	if approval == nil {
+		return nil, exception.New(constants.ERROR_APPROVAL_NOT_FOUND, exception.Params{"approvalId": requestVM.ApprovalId})
+	}
+
+	if approval.ApprovalStatus != constants.APPROVAL_STATUS_PENDING {
+		return nil, exception.New(constants.ERROR_APPROVAL_ALREADY_DECIDED, exception.Params{"approvalId": approval.ApprovalId})
+	}
+
+	checker := authenticatedUser(c, requestVM.ApprovalUser)
+	if checker == "" {
+		return nil, exception.New(constants.ERROR_APPROVAL_USER_REQUIRED, nil)
+	}
+
+	if checker == approval.RequestUser {
+		return nil, exception.New(constants.ERROR_APPROVAL_USER_SAME_AS_REQUESTER, nil)
+	}
+
+	// Process
+	now := time.Now()
+	decision := *approval
+	decision.ApprovalUser = &checker
+	decision.ApprovalDate = &now
+	if requestVM.ApprovalReason != "" {
+		decision.ApprovalReason = &requestVM.ApprovalReason
+	}
+
+	if strings.HasPrefix(action, "A") {
+		decision.ApprovalStatus = constants.APPROVAL_STATUS_APPROVED
+	} else {
+		decision.ApprovalStatus = constants.APPROVAL_STATUS_REJECTED
+	}
+
+	// The decision and the applied sample commit or roll back together
+	err = s.cfg.Transaction(c, func(c context.Context) error {
+		decided, err := s.repository.SetSampleApprovalDecision(c, constants.APPROVAL_STATUS_PENDING, &decision)
+		if err != nil {
+			return helper.CatchErr(err)
+		}
+
+		if !decided {
+			return exception.New(constants.ERROR_APPROVAL_ALREADY_DECIDED, exception.Params{"approvalId": approval.ApprovalId})
+		}
+
+		if decision.ApprovalStatus == constants.APPROVAL_STATUS_APPROVED {
+			err = s.apply(c, &decision)
+			if err != nil {
+				return helper.CatchErr(err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert To View Model
+	return toSampleApprovalViewModel(s.cfg, &decision), nil
+}
+
+// apply writes the staged sample. Only its content is taken from the draft,
+// the maker and checker are stamped from the approval and an update keeps the
+// rest of the current row. A sample deleted since the draft was staged is not
+// brought back.
+func (s *SampleApprovalServiceImpl) apply(c context.Context, approval *model.SampleApprovalModel) error {
+	draft := &model.SampleModel{}
+	if approval.ApprovalData != nil {
+		err := json.Unmarshal([]byte(*approval.ApprovalData), draft)
+		if err != nil {
+			return err
+		}
+	}
+
+	if approval.ApprovalAction == constants.APPROVAL_ACTION_INSERT {
+		sampleM := &model.SampleModel{
+			SampleId:          approval.SampleId,
+			SampleType:        draft.SampleType,
+			SampleName:        draft.SampleName,
+			SampleDescription: draft.SampleDescription,
+			CreateDate:        approval.ApprovalDate,
+			CreateUser:        approval.RequestUser,
+			CreateApprover:    *approval.ApprovalUser,
+		}
+		return s.sampleRepository.SetSample(c, approval.ApprovalAction, sampleM)
+	}
+
+	// Locked, so a concurrent activation cannot be overwritten with what was read
+	current, err := s.sampleRepository.LockSample(c, &model.SampleQueryModel{SampleId: approval.SampleId})
+	if err != nil {
+		return err
+	}
+
+	if current == nil {
+		return exception.New(constants.ERROR_SAMPLE_NOT_FOUND, exception.Params{"sampleId": approval.SampleId})
+	}
+
+	if approval.ApprovalAction == constants.APPROVAL_ACTION_UPDATE {
+		// The active version is owned by activate / rollback, keep whatever is current
+		current.SampleType = draft.SampleType
+		current.SampleName = draft.SampleName
+		current.SampleDescription = draft.SampleDescription
+		current.UpdateDate = approval.ApprovalDate
+		current.UpdateUser = approval.RequestUser
+		current.UpdateApprover = *approval.ApprovalUser
+	}
+
+	return s.sampleRepository.SetSample(c, approval.ApprovalAction, current)
+}
+
+func toSampleApprovalViewModel(cfg *bootstrap.Container, approval *model.SampleApprovalModel) *viewmodel.SampleApprovalRsViewModel {
+	responseVM := &viewmodel.SampleApprovalRsViewModel{}
+	cfg.CopyStruct(approval, responseVM)
+
+	if approval.ApprovalData != nil {
+		sampleM := &model.SampleModel{}
+		if err := json.Unmarshal([]byte(*approval.ApprovalData), sampleM); err == nil {
+			responseVM.Sample = &viewmodel.SampleRsViewModel{}
+			cfg.CopyStruct(sampleM, responseVM.Sample)
+		}
+	}
+
+	return responseVM
+}
@@ -2,13 +2,22 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"gogin-template/baselib/dto"
 	"gogin-template/baselib/exception"
 	"gogin-template/baselib/helper"
+	"gogin-template/baselib/identifier"
 	"gogin-template/bootstrap"
+	"gogin-template/internal/constants"
 	"gogin-template/internal/model"
 	"gogin-template/internal/repository"
 	"gogin-template/internal/viewmodel"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 type SampleService interface {
@@ -16,18 +25,21 @@ type SampleService interface {
 	GetSample(c context.Context, requestVM *viewmodel.SampleRqViewModel) (*viewmodel.SampleRsViewModel, error)
 	GetSampleVersions(c context.Context, requestVM *viewmodel.SampleVersionRqViewModel) (*[]viewmodel.SampleVersionRsViewModel, error)
 	GetSampleVersion(c context.Context, requestVM *viewmodel.SampleVersionRqViewModel) (*viewmodel.SampleVersionRsViewModel, error)
-	SetSample(c context.Context, action string, requestVM *viewmodel.SampleRqViewModel) error
+	SetSample(c context.Context, action string, requestVM *viewmodel.SampleRqViewModel) (*viewmodel.SampleApprovalRsViewModel, error)
 	SetSampleVersions(c context.Context, requestVM *[]viewmodel.SampleVersionRqViewModel) error
 	SetSampleVersion(c context.Context, action string, requestVM *viewmodel.SampleVersionRqViewModel) error
+	SetSampleActiveVersion(c context.Context, action string, requestVM *viewmodel.SampleVersionRqViewModel) (*viewmodel.SampleRsViewModel, error)
+	SetSampleRelease(c context.Context, requestVM *viewmodel.SampleReleaseRqViewModel) (*viewmodel.SampleRsViewModel, error)
 }
 
 type SampleServiceImpl struct {
-	repository repository.SampleRepository
-	cfg        *bootstrap.Container
+	repository         repository.SampleRepository
+	approvalRepository repository.SampleApprovalRepository
+	cfg                *bootstrap.Container
 }
 
-func NewSampleService(repository repository.SampleRepository, cfg *bootstrap.Container) SampleService {
-	return &SampleServiceImpl{repository: repository, cfg: cfg}
+func NewSampleService(repository repository.SampleRepository, approvalRepository repository.SampleApprovalRepository, cfg *bootstrap.Container) SampleService {
+	return &SampleServiceImpl{repository: repository, approvalRepository: approvalRepository, cfg: cfg}
 }
 
 func (s *SampleServiceImpl) GetSamples(c context.Context, requestVM *viewmodel.SampleRqViewModel, dtoPage dto.PageRequest) (*[]viewmodel.SampleRsViewModel, *dto.PageInfo, error) {
@@ -62,7 +74,7 @@ func (s *SampleServiceImpl) GetSample(c context.Context, requestVM *viewmodel.Sa
 	}
 
 	if response == nil {
-		return nil, exception.NotFoundException("404", "Not Found")
+		return nil, exception.New(constants.ERROR_SAMPLE_NOT_FOUND, exception.Params{"sampleId": requestM.SampleId})
 	}
 
 	// Convert To View Model
@@ -74,9 +86,7 @@ func (s *SampleServiceImpl) GetSample(c context.Context, requestVM *viewmodel.Sa
 
 func (s *SampleServiceImpl) GetSampleVersions(c context.Context, requestVM *viewmodel.SampleVersionRqViewModel) (*[]viewmodel.SampleVersionRsViewModel, error) {
 	// Convert View Model to Model
-	requestM := &model.SampleVersionQueryModel{}
-
-	s.cfg.CopyStruct(requestM, requestVM)
+	requestM := &model.SampleVersionQueryModel{SampleId: requestVM.SampleId, SampleVersions: requestVM.VersionNumber}
 
 	// Process
 	response, err := s.repository.GetSampleVersions(c, requestM)
@@ -93,9 +103,7 @@ func (s *SampleServiceImpl) GetSampleVersions(c context.Context, requestVM *view
 
 func (s *SampleServiceImpl) GetSampleVersion(c context.Context, requestVM *viewmodel.SampleVersionRqViewModel) (*viewmodel.SampleVersionRsViewModel, error) {
 	// Convert View Model to Model
-	requestM := &model.SampleVersionQueryModel{}
-
-	s.cfg.CopyStruct(requestM, requestVM)
+	requestM := &model.SampleVersionQueryModel{SampleId: requestVM.SampleId, SampleVersions: requestVM.VersionNumber}
 
 	// Process
 	response, err := s.repository.GetSampleVersion(c, requestM)
@@ -110,18 +118,127 @@ func (s *SampleServiceImpl) GetSampleVersion(c context.Context, requestVM *viewm
 	return responseVM, nil
 }
 
-func (s *SampleServiceImpl) SetSample(c context.Context, action string, requestVM *viewmodel.SampleRqViewModel) error {
+// SetSample stages the change as a pending approval instead of writing it,
+// it is applied once a different user approves it through SampleApprovalService.
+func (s *SampleServiceImpl) SetSample(c context.Context, action string, requestVM *viewmodel.SampleRqViewModel) (*viewmodel.SampleApprovalRsViewModel, error) {
 	// Convert View Model to Model
 	requestM := &model.SampleModel{}
 
 	s.cfg.CopyStruct(requestVM, requestM)
 
-	// Process
-	err := s.repository.SetSample(c, action, requestM)
+	// Validate
+	approvalAction := constants.APPROVAL_ACTION_UPDATE
+	requestM.UpdateUser = authenticatedUser(c, requestM.UpdateUser)
+	requestUser := requestM.UpdateUser
+	if strings.HasPrefix(action, "I") {
+		approvalAction = constants.APPROVAL_ACTION_INSERT
+		requestM.CreateUser = authenticatedUser(c, requestM.CreateUser)
+		requestUser = requestM.CreateUser
+	} else if strings.HasPrefix(action, "D") {
+		approvalAction = constants.APPROVAL_ACTION_DELETE
+	}
+
+	if requestM.SampleId == "" {
+		return nil, exception.New(constants.ERROR_SAMPLE_ID_REQUIRED, nil)
+	}
+
+	if requestUser == "" {
+		return nil, exception.New(constants.ERROR_REQUEST_USER_REQUIRED, nil)
+	}
+
+	err := helper.ValidateStruct(c, s.cfg, requestM)
+	if err != nil {
+		return nil, err
+	}
+
+	// Process, only the content is staged. Users, approvers and dates are
+	// stamped from the approval once it is applied.
+	draft := &model.SampleModel{
+		SampleId:          requestM.SampleId,
+		SampleType:        requestM.SampleType,
+		SampleName:        requestM.SampleName,
+		SampleDescription: requestM.SampleDescription,
+	}
+	data, err := json.Marshal(draft)
+	if err != nil {
+		return nil, helper.CatchErr(err)
+	}
+
+	now := time.Now()
+	approvalData := string(data)
+	approvalM := &model.SampleApprovalModel{
+		ApprovalId:     uuid.NewString(),
+		SampleId:       requestM.SampleId,
+		ApprovalAction: approvalAction,
+		ApprovalStatus: constants.APPROVAL_STATUS_PENDING,
+		ApprovalData:   &approvalData,
+		RequestDate:    &now,
+		RequestUser:    requestUser,
+	}
+
+	// The checks read the primary in the same unit as the insert, a change
+	// staged in between is turned away by the index on pending approvals
+	err = s.cfg.Transaction(c, func(c context.Context) error {
+		current, err := s.repository.GetSample(c, &model.SampleQueryModel{SampleId: requestM.SampleId})
+		if err != nil {
+			return helper.CatchErr(err)
+		}
+
+		if current != nil && approvalAction == constants.APPROVAL_ACTION_INSERT {
+			return exception.New(constants.ERROR_SAMPLE_ALREADY_EXISTS, exception.Params{"sampleId": requestM.SampleId})
+		}
+
+		if current == nil && approvalAction != constants.APPROVAL_ACTION_INSERT {
+			return exception.New(constants.ERROR_SAMPLE_NOT_FOUND, exception.Params{"sampleId": requestM.SampleId})
+		}
+
+		if approvalAction != constants.APPROVAL_ACTION_DELETE && requestM.SampleActiveVersion != "" &&
+			(current == nil || current.SampleActiveVersion != requestM.SampleActiveVersion) {
+			return exception.New(constants.ERROR_SAMPLE_ACTIVE_VERSION_READONLY, nil)
+		}
+
+		err = s.checkPendingChange(c, requestM.SampleId)
+		if err != nil {
+			return err
+		}
+
+		// In a savepoint, so the pending approval can still be read when it fails
+		err = s.cfg.Transaction(c, func(c context.Context) error {
+			return s.approvalRepository.SetSampleApproval(c, "Insert", approvalM)
+		})
+		if errors.Is(err, repository.ErrApprovalPending) {
+			if err := s.checkPendingChange(c, requestM.SampleId); err != nil {
+				return err
+			}
+		}
+		if err != nil {
+			return helper.CatchErr(err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert To View Model
+	return toSampleApprovalViewModel(s.cfg, approvalM), nil
+}
+
+// checkPendingChange fails with the approval still pending for sampleId, if any.
+func (s *SampleServiceImpl) checkPendingChange(c context.Context, sampleId string) error {
+	pending, err := s.approvalRepository.GetSampleApproval(c, &model.SampleApprovalQueryModel{
+		SampleId:       sampleId,
+		ApprovalStatus: constants.APPROVAL_STATUS_PENDING,
+	})
 	if err != nil {
 		return helper.CatchErr(err)
 	}
 
+	if pending != nil {
+		return exception.New(constants.ERROR_SAMPLE_PENDING_CHANGE, exception.Params{"approvalId": pending.ApprovalId})
+	}
+
 	return nil
 }
 
@@ -131,6 +248,18 @@ func (s *SampleServiceImpl) SetSampleVersions(c context.Context, requestVM *[]vi
 
 	s.cfg.CopyStruct(requestVM, requestM)
 
+	for i := range *requestM {
+		(*requestM)[i].CreateUser = authenticatedUser(c, (*requestM)[i].CreateUser)
+	}
+
+	// Validate
+	for i := range *requestM {
+		err := helper.ValidateStruct(c, s.cfg, &(*requestM)[i])
+		if err != nil {
+			return err
+		}
+	}
+
 	// Process
 	err := s.repository.SetSampleVersions(c, requestM)
 	if err != nil {
@@ -146,11 +275,204 @@ func (s *SampleServiceImpl) SetSampleVersion(c context.Context, action string, r
 
 	s.cfg.CopyStruct(requestVM, requestM)
 
+	if strings.HasPrefix(action, "I") {
+		requestM.CreateUser = authenticatedUser(c, requestM.CreateUser)
+	} else {
+		requestM.UpdateUser = authenticatedUser(c, requestM.UpdateUser)
+	}
+
+	// Validate, a delete only names an existing version which may predate the
+	// current rules, e.g. the four part numbers stored before semantic versions
+	if !strings.HasPrefix(action, "D") {
+		err := helper.ValidateStruct(c, s.cfg, requestM)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !strings.HasPrefix(action, "D") {
+		err := s.repository.SetSampleVersion(c, action, requestM)
+		if err != nil {
+			return helper.CatchErr(err)
+		}
+
+		return nil
+	}
+
+	// Process, the sample stays locked until the version is deleted so it
+	// cannot be activated in between
+	return s.cfg.Transaction(c, func(c context.Context) error {
+		sample, err := s.repository.LockSample(c, &model.SampleQueryModel{SampleId: requestM.SampleId})
+		if err != nil {
+			return helper.CatchErr(err)
+		}
+
+		if sample != nil && sample.SampleActiveVersion == requestM.VersionNumber {
+			return exception.New(constants.ERROR_SAMPLE_ACTIVE_VERSION_DELETE, nil)
+		}
+
+		err = s.repository.SetSampleVersion(c, action, requestM)
+		if err != nil {
+			return helper.CatchErr(err)
+		}
+
+		return nil
+	})
+}
+
+// SetSampleActiveVersion activates requestVM.VersionNumber, or with the
+// Rollback action restores the version that was active before the latest
+// activation that has not been rolled back yet.
+func (s *SampleServiceImpl) SetSampleActiveVersion(c context.Context, action string, requestVM *viewmodel.SampleVersionRqViewModel) (*viewmodel.SampleRsViewModel, error) {
+	// Validate
+	sample, err := s.repository.GetSample(c, &model.SampleQueryModel{SampleId: requestVM.SampleId})
+	if err != nil {
+		return nil, helper.CatchErr(err)
+	}
+
+	if sample == nil || sample.SampleId == "" {
+		return nil, exception.New(constants.ERROR_SAMPLE_NOT_FOUND, exception.Params{"sampleId": requestVM.SampleId})
+	}
+
+	historyAction := constants.VERSION_ACTION_ACTIVATE
+	versionNumber := requestVM.VersionNumber
+	if strings.HasPrefix(action, "R") {
+		historyAction = constants.VERSION_ACTION_ROLLBACK
+		versionNumber, err = s.getRollbackVersion(c, sample.SampleId)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if versionNumber == sample.SampleActiveVersion {
+		return nil, exception.New(constants.ERROR_SAMPLE_VERSION_ALREADY_ACTIVE, exception.Params{"versionNumber": versionNumber})
+	}
+
+	version, err := s.repository.GetSampleVersion(c, &model.SampleVersionQueryModel{SampleId: sample.SampleId, SampleVersions: versionNumber})
+	if err != nil {
+		return nil, helper.CatchErr(err)
+	}
+
+	if version == nil || version.VersionNumber == "" {
+		return nil, exception.New(constants.ERROR_SAMPLE_VERSION_NOT_FOUND, exception.Params{"versionNumber": versionNumber})
+	}
+
 	// Process
-	err := s.repository.SetSampleVersion(c, action, requestM)
+	return s.activate(c, sample, versionNumber, historyAction, authenticatedUser(c, requestVM.UpdateUser))
+}
+
+// SetSampleRelease inserts new versions of a sample and activates one of them
+// in one unit of work, so a failed activation leaves no versions behind.
+func (s *SampleServiceImpl) SetSampleRelease(c context.Context, requestVM *viewmodel.SampleReleaseRqViewModel) (*viewmodel.SampleRsViewModel, error) {
+	// Validate
+	released := false
+	for _, version := range requestVM.Versions {
+		released = released || version.VersionNumber == requestVM.ActiveVersion
+	}
+
+	if !released {
+		return nil, exception.New(constants.ERROR_SAMPLE_VERSION_NOT_FOUND, exception.Params{"versionNumber": requestVM.ActiveVersion})
+	}
+
+	// Process, the sample stays locked until the release commits
+	var responseVM *viewmodel.SampleRsViewModel
+	err := s.cfg.Transaction(c, func(c context.Context) error {
+		sample, err := s.repository.LockSample(c, &model.SampleQueryModel{SampleId: requestVM.SampleId})
+		if err != nil {
+			return helper.CatchErr(err)
+		}
+
+		if sample == nil {
+			return exception.New(constants.ERROR_SAMPLE_NOT_FOUND, exception.Params{"sampleId": requestVM.SampleId})
+		}
+
+		for _, version := range requestVM.Versions {
+			version.SampleId = sample.SampleId
+			err := s.SetSampleVersion(c, "Insert", &version)
+			if err != nil {
+				return err
+			}
+		}
+
+		responseVM, err = s.activate(c, sample, requestVM.ActiveVersion, constants.VERSION_ACTION_ACTIVATE, authenticatedUser(c, requestVM.UpdateUser))
+		return err
+	})
 	if err != nil {
-		return helper.CatchErr(err)
+		return nil, err
 	}
 
-	return nil
+	return responseVM, nil
+}
+
+// activate points sample at versionNumber and records the history entry.
+func (s *SampleServiceImpl) activate(c context.Context, sample *model.SampleModel, versionNumber string, historyAction string, updateUser string) (*viewmodel.SampleRsViewModel, error) {
+	now := time.Now()
+	history := &model.SampleVersionHistoryModel{
+		HistoryId:       uuid.NewString(),
+		SampleId:        sample.SampleId,
+		VersionNumber:   versionNumber,
+		PreviousVersion: sample.SampleActiveVersion,
+		HistoryAction:   historyAction,
+		CreateDate:      &now,
+		CreateUser:      updateUser,
+	}
+
+	sample.SampleActiveVersion = versionNumber
+	sample.UpdateDate = &now
+	sample.UpdateUser = updateUser
+
+	err := s.repository.SetSampleActiveVersion(c, sample, history)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, exception.New(constants.ERROR_SAMPLE_ACTIVE_VERSION_CONFLICT, nil)
+	}
+	if err != nil {
+		return nil, helper.CatchErr(err)
+	}
+
+	// Convert To View Model
+	responseVM := &viewmodel.SampleRsViewModel{}
+	s.cfg.CopyStruct(sample, responseVM)
+
+	return responseVM, nil
+}
+
+// getRollbackVersion walks the history newest first, letting every rollback
+// cancel out one activation, and returns the version active before the first
+// activation left over.
+func (s *SampleServiceImpl) getRollbackVersion(c context.Context, sampleId string) (string, error) {
+	histories, err := s.repository.GetSampleVersionHistories(c, &model.SampleVersionHistoryQueryModel{SampleId: sampleId})
+	if err != nil {
+		return "", helper.CatchErr(err)
+	}
+
+	rolledBack := 0
+	for _, history := range *histories {
+		if history.HistoryAction == constants.VERSION_ACTION_ROLLBACK {
+			rolledBack++
+			continue
+		}
+
+		if rolledBack > 0 {
+			rolledBack--
+			continue
+		}
+
+		if history.PreviousVersion == "" {
+			break
+		}
+
+		return history.PreviousVersion, nil
+	}
+
+	return "", exception.New(constants.ERROR_SAMPLE_NO_ROLLBACK_VERSION, nil)
+}
+
+// authenticatedUser prefers the subject of the bearer token over the user id
+// sent in the request body, which is only used on routes without AuthMiddleware.
+func authenticatedUser(c context.Context, requestUser string) string {
+	if subject := identifier.GetSubject(c); subject != "" {
+		return subject
+	}
+
+	return requestUser
 }
@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"gogin-template/baselib/exception"
+	"gogin-template/bootstrap"
+	"gogin-template/internal/constants"
+	"gogin-template/internal/model"
+	"gogin-template/internal/repository"
+	"gogin-template/internal/viewmodel"
+	"reflect"
+	"testing"
+)
+
+// sampleTestRepository serves one sample and records the versions written,
+// the methods a test does not stub panic through the nil interface.
+type sampleTestRepository struct {
+	repository.SampleRepository
+	sample    *model.SampleModel
+	locked    *model.SampleModel // Row seen under the lock when it differs from sample
+	versions  []string
+	activeErr error
+
+	// Writes go through the write database of cfg when set
+	cfg *bootstrap.Container
+}
+
+func (r *sampleTestRepository) GetSample(c context.Context, obj *model.SampleQueryModel) (*model.SampleModel, error) {
+	return r.sample, nil
+}
+
+func (r *sampleTestRepository) LockSample(c context.Context, obj *model.SampleQueryModel) (*model.SampleModel, error) {
+	if r.locked != nil {
+		return r.locked, r.exec(c, "LOCK "+obj.SampleId)
+	}
+
+	return r.sample, r.exec(c, "LOCK "+obj.SampleId)
+}
+
+func (r *sampleTestRepository) SetSampleVersion(c context.Context, action string, obj *model.SampleVersionModel) error {
+	r.versions = append(r.versions, action+" "+obj.VersionNumber)
+	return r.exec(c, "INSERT "+obj.VersionNumber)
+}
+
+func (r *sampleTestRepository) SetSampleActiveVersion(c context.Context, obj *model.SampleModel, history *model.SampleVersionHistoryModel) error {
+	if r.activeErr != nil {
+		return r.activeErr
+	}
+
+	return r.exec(c, "ACTIVATE "+obj.SampleActiveVersion)
+}
+
+func (r *sampleTestRepository) exec(c context.Context, statement string) error {
+	if r.cfg == nil {
+		return nil
+	}
+
+	db, err := r.cfg.Sqlx(c, r.cfg.Dbw())
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(c, statement)
+	return err
+}
+
+// sampleTestApprovalRepository answers the pending approval reads in order
+// and fails the insert with insertErr.
+type sampleTestApprovalRepository struct {
+	repository.SampleApprovalRepository
+	pending   []*model.SampleApprovalModel
+	insertErr error
+	inserted  []string
+}
+
+func (r *sampleTestApprovalRepository) GetSampleApproval(c context.Context, obj *model.SampleApprovalQueryModel) (*model.SampleApprovalModel, error) {
+	if len(r.pending) == 0 {
+		return nil, nil
+	}
+
+	pending := r.pending[0]
+	r.pending = r.pending[1:]
+	return pending, nil
+}
+
+func (r *sampleTestApprovalRepository) SetSampleApproval(c context.Context, action string, obj *model.SampleApprovalModel) error {
+	if r.insertErr != nil {
+		return r.insertErr
+	}
+
+	r.inserted = append(r.inserted, obj.ApprovalAction+" "+obj.SampleId)
+	return nil
+}
+
+// sampleTestDriver is a database/sql driver recording the statements run and
+// how their transaction ended, registered as database.driver sampletest.
+type sampleTestDriver struct{ statements []string }
+
+type sampleTestConn struct{ driver *sampleTestDriver }
+
+var sampleTestDatabase = &sampleTestDriver{}
+
+func init() {
+	sql.Register("sampletest", sampleTestDatabase)
+}
+
+func (d *sampleTestDriver) Open(string) (driver.Conn, error) { return sampleTestConn{d}, nil }
+
+func (c sampleTestConn) Prepare(string) (driver.Stmt, error) { return nil, errors.New("not supported") }
+func (c sampleTestConn) Close() error                        { return nil }
+func (c sampleTestConn) Begin() (driver.Tx, error)           { return c, nil }
+func (c sampleTestConn) Commit() error                       { return c.record("COMMIT") }
+func (c sampleTestConn) Rollback() error                     { return c.record("ROLLBACK") }
+
+func (c sampleTestConn) ExecContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Result, error) {
+	return driver.RowsAffected(1), c.record(query)
+}
+
+func (c sampleTestConn) record(statement string) error {
+	c.driver.statements = append(c.driver.statements, statement)
+	return nil
+}
+
+func TestSetSampleVersion(t *testing.T) {
+	cfg := bootstrap.Init()
+
+	tests := []struct {
+		name    string
+		action  string
+		version string
+		locked  string
+		code    string
+	}{
+		{name: "insert", action: "I", version: "1.2.3"},
+		{name: "insert legacy version", action: "I", version: "1.2.3.4", code: exception.ERROR_VALIDATION_FAILED},
+		{name: "delete", action: "D", version: "1.2.3"},
+		{name: "delete legacy version", action: "D", version: "1.2.3.4"},
+		{name: "delete active version", action: "D", version: "2.0.0", code: constants.ERROR_SAMPLE_ACTIVE_VERSION_DELETE},
+		{name: "delete version activated concurrently", action: "D", version: "1.2.3", locked: "1.2.3", code: constants.ERROR_SAMPLE_ACTIVE_VERSION_DELETE},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := &sampleTestRepository{sample: &model.SampleModel{SampleId: "S1", SampleActiveVersion: "2.0.0"}}
+			if test.locked != "" {
+				repo.locked = &model.SampleModel{SampleId: "S1", SampleActiveVersion: test.locked}
+			}
+			service := NewSampleService(repo, nil, cfg)
+
+			err := service.SetSampleVersion(context.Background(), test.action, &viewmodel.SampleVersionRqViewModel{SampleId: "S1", VersionNumber: test.version})
+			if test.code != "" {
+				var e *exception.ErrorException
+				if !errors.As(err, &e) || e.ErrorCode != test.code {
+					t.Fatalf("error = %v, want %s", err, test.code)
+				}
+				if len(repo.versions) != 0 {
+					t.Errorf("versions = %v, want nothing written", repo.versions)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(repo.versions) != 1 || repo.versions[0] != test.action+" "+test.version {
+				t.Errorf("versions = %v, want %s %s written", repo.versions, test.action, test.version)
+			}
+		})
+	}
+}
+
+func TestSetSampleRelease(t *testing.T) {
+	t.Setenv("APP_DATABASE_DRIVER", "sampletest")
+	cfg := bootstrap.Init()
+
+	tests := []struct {
+		name       string
+		missing    bool
+		active     string
+		activeErr  error
+		code       string
+		statements []string
+	}{
+		{
+			name:       "versions and activation commit together",
+			active:     "1.1.0",
+			statements: []string{"LOCK S1", "INSERT 1.0.1", "INSERT 1.1.0", "ACTIVATE 1.1.0", "COMMIT"},
+		},
+		{
+			name:       "failed activation rolls back the versions",
+			active:     "1.1.0",
+			activeErr:  sql.ErrNoRows,
+			code:       constants.ERROR_SAMPLE_ACTIVE_VERSION_CONFLICT,
+			statements: []string{"LOCK S1", "INSERT 1.0.1", "INSERT 1.1.0", "ROLLBACK"},
+		},
+		{
+			name:       "sample removed before the lock",
+			missing:    true,
+			active:     "1.1.0",
+			code:       constants.ERROR_SAMPLE_NOT_FOUND,
+			statements: []string{"LOCK S1", "ROLLBACK"},
+		},
+		{
+			name:   "active version outside the release",
+			active: "2.0.0",
+			code:   constants.ERROR_SAMPLE_VERSION_NOT_FOUND,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sampleTestDatabase.statements = nil
+			repo := &sampleTestRepository{
+				sample:    &model.SampleModel{SampleId: "S1", SampleActiveVersion: "1.0.0"},
+				activeErr: test.activeErr,
+				cfg:       cfg,
+			}
+			if test.missing {
+				repo.sample = nil
+			}
+			service := NewSampleService(repo, nil, cfg)
+
+			_, err := service.SetSampleRelease(context.Background(), &viewmodel.SampleReleaseRqViewModel{
+				SampleId:      "S1",
+				ActiveVersion: test.active,
+				Versions:      []viewmodel.SampleVersionRqViewModel{{VersionNumber: "1.0.1"}, {VersionNumber: "1.1.0"}},
+			})
+
+			var e *exception.ErrorException
+			if test.code == "" && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else if test.code != "" && (!errors.As(err, &e) || e.ErrorCode != test.code) {
+				t.Fatalf("error = %v, want %s", err, test.code)
+			}
+
+			if !reflect.DeepEqual(sampleTestDatabase.statements, test.statements) {
+				t.Errorf("statements = %q, want %q", sampleTestDatabase.statements, test.statements)
+			}
+		})
+	}
+}
+
+func TestSetSample(t *testing.T) {
+	cfg := bootstrap.Init()
+
+	tests := []struct {
+		name       string
+		action     string
+		existing   bool
+		pending    []*model.SampleApprovalModel
+		insertErr  error
+		code       string
+		approvalId string
+	}{
+		{name: "insert", action: "I"},
+		{name: "insert existing sample", action: "I", existing: true, code: constants.ERROR_SAMPLE_ALREADY_EXISTS},
+		{name: "update", action: "U", existing: true},
+		{name: "update missing sample", action: "U", code: constants.ERROR_SAMPLE_NOT_FOUND},
+		{
+			name:       "pending change",
+			action:     "U",
+			existing:   true,
+			pending:    []*model.SampleApprovalModel{{ApprovalId: "A1"}},
+			code:       constants.ERROR_SAMPLE_PENDING_CHANGE,
+			approvalId: "A1",
+		},
+		{
+			name:       "change staged concurrently",
+			action:     "U",
+			existing:   true,
+			pending:    []*model.SampleApprovalModel{nil, {ApprovalId: "A2"}},
+			insertErr:  repository.ErrApprovalPending,
+			code:       constants.ERROR_SAMPLE_PENDING_CHANGE,
+			approvalId: "A2",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			repo := &sampleTestRepository{}
+			if test.existing {
+				repo.sample = &model.SampleModel{SampleId: "S1"}
+			}
+			approvalRepo := &sampleTestApprovalRepository{pending: test.pending, insertErr: test.insertErr}
+			service := NewSampleService(repo, approvalRepo, cfg)
+
+			_, err := service.SetSample(context.Background(), test.action, &viewmodel.SampleRqViewModel{
+				SampleId:   "S1",
+				SampleName: "Sample",
+				CreateUser: "maker",
+				UpdateUser: "maker",
+			})
+			if test.code == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if len(approvalRepo.inserted) != 1 {
+					t.Errorf("inserted = %v, want one approval staged", approvalRepo.inserted)
+				}
+				return
+			}
+
+			var e *exception.ErrorException
+			if !errors.As(err, &e) || e.ErrorCode != test.code {
+				t.Fatalf("error = %v, want %s", err, test.code)
+			}
+			if test.approvalId != "" && e.Params["approvalId"] != test.approvalId {
+				t.Errorf("approvalId = %v, want %s", e.Params["approvalId"], test.approvalId)
+			}
+			if len(approvalRepo.inserted) != 0 {
+				t.Errorf("inserted = %v, want nothing staged", approvalRepo.inserted)
+			}
+		})
+	}
+}
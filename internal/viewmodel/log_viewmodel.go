@@ -0,0 +1,18 @@
+package viewmodel
+
+import "time"
+
+// LogLevelRqViewModel info
+// @Description Log Level Change Request
+type LogLevelRqViewModel struct {
+	Level    string `json:"level" validate:"required,oneof=trace debug info warn error" example:"debug"` // Log level to switch to
+	Duration string `json:"duration,omitempty" example:"15m"`                                            // How long the level applies before reverting to log.level, empty keeps it
+}
+
+// LogLevelRsViewModel info
+// @Description Log Level Response
+type LogLevelRsViewModel struct {
+	Level           string     `json:"level" example:"debug"`                             // Current log level
+	ConfiguredLevel string     `json:"configuredLevel" example:"info"`                    // Level from log.level
+	ExpiresAt       *time.Time `json:"expiresAt,omitempty" example:"2001-01-01 01:01:01"` // When the current level reverts to log.level
+}
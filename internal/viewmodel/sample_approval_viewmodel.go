@@ -0,0 +1,28 @@
+package viewmodel
+
+import "time"
+
+// SampleApprovalRqViewModel info
+// @Description Sample Approval Request
+type SampleApprovalRqViewModel struct {
+	ApprovalId     string `json:"approvalId,omitempty" example:"0b5e4f2c-7f4e-4b8e-9d0a-2f1d1c3b4a5e"`                                // Identification for Approval
+	SampleId       string `json:"sampleId,omitempty" form:"sampleId" example:"SampleId00001"`                                         // Identification for Sample
+	ApprovalStatus string `json:"approvalStatus,omitempty" form:"approvalStatus" example:"PENDING" enums:"PENDING,APPROVED,REJECTED"` // Status of Approval (PENDING,APPROVED,REJECTED)
+	ApprovalReason string `json:"approvalReason,omitempty" example:"Reason Reason Reason"`                                            // Reason for the Decision (freetext)
+	ApprovalUser   string `json:"approvalUser,omitempty" example:"22222"`                                                             // Approver (Checker) User ID
+}
+
+// SampleApprovalRsViewModel info
+// @Description Sample Approval Response
+type SampleApprovalRsViewModel struct {
+	ApprovalId     string             `json:"approvalId,omitempty" example:"0b5e4f2c-7f4e-4b8e-9d0a-2f1d1c3b4a5e"`          // Identification for Approval
+	SampleId       string             `json:"sampleId,omitempty" example:"SampleId00001"`                                   // Identification for Sample
+	ApprovalAction string             `json:"approvalAction,omitempty" example:"Insert" enums:"Insert,Update,Delete"`       // Staged Action (Insert,Update,Delete)
+	ApprovalStatus string             `json:"approvalStatus,omitempty" example:"PENDING" enums:"PENDING,APPROVED,REJECTED"` // Status of Approval (PENDING,APPROVED,REJECTED)
+	ApprovalReason *string            `json:"approvalReason,omitempty" example:"Reason Reason Reason"`                      // Reason for the Decision (freetext)
+	Sample         *SampleRsViewModel `json:"sample,omitempty"`                                                             // Staged Sample Data
+	RequestDate    *time.Time         `json:"requestDate,omitempty" example:"2001-01-01 01:01:01"`                          // Requested Date & Time
+	RequestUser    string             `json:"requestUser,omitempty" example:"11111"`                                        // Requester (Maker) User ID
+	ApprovalDate   *time.Time         `json:"approvalDate,omitempty" example:"2002-02-02 02:02:02"`                         // Decision Date & Time
+	ApprovalUser   *string            `json:"approvalUser,omitempty" example:"22222"`                                       // Approver (Checker) User ID
+}
@@ -9,7 +9,7 @@ type SampleRqViewModel struct {
 	SampleType          string     `json:"sampleType,omitempty" example:"Type_A" enums:"Type_A,Type_B,Type_C"`        // Type of Sample (Type_A,Type_B,Type_C)
 	SampleName          string     `json:"sampleName,omitempty" example:"Sample Name 1"`                              // Name of Sample (freetext)
 	SampleDescription   string     `json:"sampleDescription,omitempty" example:"Description Description Description"` // Description of Sample (freetext)
-	SampleActiveVersion string     `json:"sampleActiveVersion,omitempty" example:"1.23.32.1"`                         // Current Active Version of Sample
+	SampleActiveVersion string     `json:"sampleActiveVersion,omitempty" example:"1.23.32"`                           // Current Active Version of Sample
 	CreateDate          *time.Time `json:"createDate,omitempty" example:"2001-01-01 01:01:01"`                        // Created Date & Time
 	CreateUser          string     `json:"createUser,omitempty" example:"11111"`                                      // Created User ID
 	CreateApprover      string     `json:"createApprover,omitempty" example:"22222"`                                  // Created Approver ID
@@ -25,7 +25,7 @@ type SampleRsViewModel struct {
 	SampleType          string                      `json:"sampleType,omitempty" example:"Type_A" enums:"Type_A,Type_B,Type_C"`        // Type of Sample (Type_A,Type_B,Type_C)
 	SampleName          string                      `json:"sampleName,omitempty" example:"Sample Name 1"`                              // Name of Sample (freetext)
 	SampleDescription   string                      `json:"sampleDescription,omitempty" example:"Description Description Description"` // Description of Sample (freetext)
-	SampleActiveVersion string                      `json:"sampleActiveVersion,omitempty" example:"1.23.32.1"`                         // Current Active Version of Sample
+	SampleActiveVersion string                      `json:"sampleActiveVersion,omitempty" example:"1.23.32"`                           // Current Active Version of Sample
 	SampleVersions      *[]SampleVersionRsViewModel `json:"sampleVersions,omitempty"`                                                  // List of All Sample Version
 	CreateDate          *time.Time                  `json:"createDate,omitempty" example:"2001-01-01 01:01:01"`                        // Created Date & Time
 	CreateUser          string                      `json:"createUser,omitempty" example:"11111"`                                      // Created User ID
@@ -39,7 +39,7 @@ type SampleRsViewModel struct {
 // @Description Sample Version Data Request
 type SampleVersionRqViewModel struct {
 	SampleId       string     `json:"sampleId,omitempty" example:"SampleId00001"`         // Idetification for Sample
-	VersionNumber  string     `json:"versionNumber,omitempty" example:"1.23.32.1"`        // Version of Sample
+	VersionNumber  string     `json:"versionNumber,omitempty" example:"1.23.32"`          // Version of Sample
 	CreateDate     *time.Time `json:"createDate,omitempty" example:"2001-01-01 01:01:01"` // Created Date & Time
 	CreateUser     string     `json:"createUser,omitempty" example:"11111"`               // Created User ID
 	CreateApprover string     `json:"createApprover,omitempty" example:"22222"`           // Created Approver ID
@@ -48,11 +48,20 @@ type SampleVersionRqViewModel struct {
 	UpdateApprover string     `json:"updateApprover,omitempty" example:"44444"`           // Last Updated Approver ID
 }
 
+// SampleReleaseRqViewModel info
+// @Description Sample Versions to Insert and the one of them to Activate
+type SampleReleaseRqViewModel struct {
+	SampleId      string                     `json:"sampleId,omitempty" example:"SampleId00001"` // Idetification for Sample
+	ActiveVersion string                     `json:"activeVersion,omitempty" example:"1.23.32"`  // Version to Activate, one of Versions
+	Versions      []SampleVersionRqViewModel `json:"versions,omitempty"`                         // Versions to Insert
+	UpdateUser    string                     `json:"updateUser,omitempty" example:"33333"`       // Last Updated User ID
+}
+
 // SampleVersionRsViewModel info
 // @Description Sample Version Data Respponse
 type SampleVersionRsViewModel struct {
 	SampleId       string     `json:"sampleId,omitempty" example:"SampleId00001"`         // Idetification for Sample
-	VersionNumber  string     `json:"versionNumber,omitempty" example:"1.23.32.1"`        // Version of Sample
+	VersionNumber  string     `json:"versionNumber,omitempty" example:"1.23.32"`          // Version of Sample
 	CreateDate     *time.Time `json:"createDate,omitempty" example:"2001-01-01 01:01:01"` // Created Date & Time
 	CreateUser     string     `json:"createUser,omitempty" example:"11111"`               // Created User ID
 	CreateApprover string     `json:"createApprover,omitempty" example:"22222"`           // Created Approver ID
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS sample.sample_version;
+
+DROP TABLE IF EXISTS sample.sample;
+
+DROP SCHEMA IF EXISTS sample;
@@ -0,0 +1,31 @@
+CREATE SCHEMA IF NOT EXISTS sample;
+
+CREATE TABLE IF NOT EXISTS sample.sample (
+	sample_id             varchar(20)   NOT NULL,
+	sample_type           varchar(20),
+	sample_name           varchar(50),
+	sample_description    varchar(1000),
+	sample_active_version varchar(10),
+	create_date           timestamp,
+	create_user           varchar(10),
+	create_approver       varchar(10),
+	update_date           timestamp,
+	update_user           varchar(10),
+	update_approver       varchar(10),
+	CONSTRAINT sample_pk PRIMARY KEY (sample_id)
+);
+
+CREATE TABLE IF NOT EXISTS sample.sample_version (
+	sample_id       varchar(20) NOT NULL,
+	version_number  varchar(10) NOT NULL,
+	create_date     timestamp,
+	create_user     varchar(10),
+	create_approver varchar(10),
+	update_date     timestamp,
+	update_user     varchar(10),
+	update_approver varchar(10),
+	CONSTRAINT sample_version_pk PRIMARY KEY (sample_id, version_number),
+	CONSTRAINT sample_version_sample_fk FOREIGN KEY (sample_id) REFERENCES sample.sample (sample_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS sample_sample_type_idx ON sample.sample (sample_type);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS sample.sample_approval (
+	approval_id     varchar(36)   NOT NULL,
+	sample_id       varchar(20)   NOT NULL,
+	approval_action varchar(10)   NOT NULL,
+	approval_status varchar(10)   NOT NULL,
+	approval_data   jsonb,
+	approval_reason varchar(1000),
+	request_date    timestamp,
+	request_user    varchar(10)   NOT NULL,
+	approval_date   timestamp,
+	approval_user   varchar(10),
+	CONSTRAINT sample_approval_pk PRIMARY KEY (approval_id),
+	CONSTRAINT sample_approval_checker_ck CHECK (approval_user IS NULL OR approval_user <> request_user)
+);
+
+CREATE INDEX IF NOT EXISTS sample_approval_sample_id_idx ON sample.sample_approval (sample_id, approval_status);
+
+CREATE UNIQUE INDEX IF NOT EXISTS sample_approval_pending_uq ON sample.sample_approval (sample_id) WHERE approval_status = 'PENDING';
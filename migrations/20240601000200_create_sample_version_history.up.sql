@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS sample.sample_version_history (
+	history_id       varchar(36) NOT NULL,
+	sample_id        varchar(20) NOT NULL,
+	version_number   varchar(10),
+	previous_version varchar(10),
+	history_action   varchar(10) NOT NULL,
+	create_date      timestamptz NOT NULL,
+	create_user      varchar(10),
+	CONSTRAINT sample_version_history_pk PRIMARY KEY (history_id),
+	CONSTRAINT sample_version_history_sample_fk FOREIGN KEY (sample_id) REFERENCES sample.sample (sample_id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS sample_version_history_sample_id_idx ON sample.sample_version_history (sample_id, create_date DESC);